@@ -0,0 +1,172 @@
+package collections
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrQueueClosed возвращается при добавлении в закрытую блокирующую
+// очередь и при извлечении из закрытой очереди, в которой не осталось
+// элементов.
+var ErrQueueClosed = errors.New("очередь закрыта")
+
+// ErrQueueFull возвращается неблокирующим Enqueue, если очередь
+// заполнена до ёмкости.
+var ErrQueueFull = errors.New("очередь заполнена")
+
+// BlockingQueue — очередь для обмена данными между горутинами. Извлечение
+// может ждать появления элемента, а при заданной ёмкости добавление ждёт
+// освобождения места. Время ожидания ограничивается контекстом, например
+// context.WithTimeout. Нулевое значение — пустая очередь без ограничения
+// ёмкости.
+type BlockingQueue[T any] struct {
+	mu       sync.Mutex
+	queue    Queue[T]
+	capacity int
+	closed   bool
+	// changed закрывается и заменяется новым каналом при каждом изменении
+	// очереди, чтобы разбудить всех ожидающих. Создаётся при первом
+	// ожидании.
+	changed chan struct{}
+}
+
+// NewBlockingQueue создает новую пустую блокирующую очередь. Если capacity
+// больше нуля, в очереди может находиться не больше capacity элементов;
+// 0 означает очередь без ограничения.
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	return &BlockingQueue[T]{capacity: max(capacity, 0)}
+}
+
+// Enqueue добавляет элемент в конец очереди без ожидания. Возвращает
+// ErrQueueFull, если места нет, и ErrQueueClosed, если очередь закрыта.
+func (q *BlockingQueue[T]) Enqueue(value T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	if q.full() {
+		return ErrQueueFull
+	}
+	q.queue.Enqueue(value)
+	q.notify()
+	return nil
+}
+
+// EnqueueWait добавляет элемент в конец очереди, ожидая свободного места.
+// Возвращает ошибку контекста, если он отменён раньше, и ErrQueueClosed,
+// если очередь закрыта.
+func (q *BlockingQueue[T]) EnqueueWait(ctx context.Context, value T) error {
+	q.mu.Lock()
+	for {
+		if q.closed {
+			q.mu.Unlock()
+			return ErrQueueClosed
+		}
+		if !q.full() {
+			break
+		}
+		if err := q.wait(ctx); err != nil {
+			return err
+		}
+	}
+	defer q.mu.Unlock()
+	q.queue.Enqueue(value)
+	q.notify()
+	return nil
+}
+
+// Dequeue извлекает элемент из начала очереди без ожидания. Для пустой
+// открытой очереди возвращает ошибку "очередь пуста", для пустой закрытой —
+// ErrQueueClosed.
+func (q *BlockingQueue[T]) Dequeue() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.queue.Len() == 0 && q.closed {
+		var zero T
+		return zero, ErrQueueClosed
+	}
+	value, err := q.queue.Dequeue()
+	if err == nil {
+		q.notify()
+	}
+	return value, err
+}
+
+// DequeueWait извлекает элемент из начала очереди, ожидая его появления.
+// Возвращает ошибку контекста, если он отменён раньше. После закрытия
+// очереди оставшиеся элементы можно извлечь, затем возвращается
+// ErrQueueClosed.
+func (q *BlockingQueue[T]) DequeueWait(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	for q.queue.Len() == 0 {
+		if q.closed {
+			q.mu.Unlock()
+			var zero T
+			return zero, ErrQueueClosed
+		}
+		if err := q.wait(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+	defer q.mu.Unlock()
+	value, err := q.queue.Dequeue()
+	q.notify()
+	return value, err
+}
+
+// Close закрывает очередь и будит всех ожидающих. Добавлять элементы в
+// закрытую очередь нельзя. Повторный вызов ничего не делает.
+func (q *BlockingQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.closed {
+		q.closed = true
+		q.notify()
+	}
+}
+
+// Len возвращает количество элементов очереди.
+func (q *BlockingQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Len()
+}
+
+// Cap возвращает ёмкость очереди; 0 означает очередь без ограничения.
+func (q *BlockingQueue[T]) Cap() int {
+	return q.capacity
+}
+
+// full сообщает, заполнена ли очередь. Вызывается под q.mu.
+func (q *BlockingQueue[T]) full() bool {
+	return q.capacity > 0 && q.queue.Len() >= q.capacity
+}
+
+// notify будит всех ожидающих изменения очереди. Вызывается под q.mu.
+func (q *BlockingQueue[T]) notify() {
+	if q.changed != nil {
+		close(q.changed)
+		q.changed = nil
+	}
+}
+
+// wait отпускает q.mu и ждёт изменения очереди или отмены ctx. При
+// изменении блокировка берётся снова и возвращается nil; при отмене
+// возвращается ошибка контекста без блокировки.
+func (q *BlockingQueue[T]) wait(ctx context.Context) error {
+	if q.changed == nil {
+		q.changed = make(chan struct{})
+	}
+	changed := q.changed
+	q.mu.Unlock()
+	select {
+	case <-changed:
+		q.mu.Lock()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,174 @@
+package collections
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueueZeroValue(t *testing.T) {
+	var queue BlockingQueue[int]
+	if err := queue.Enqueue(1); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if value, err := queue.DequeueWait(context.Background()); err != nil || value != 1 {
+		t.Fatalf("DequeueWait = %d, %v, ожидалось 1", value, err)
+	}
+	queue.Close()
+	if _, err := queue.Dequeue(); err != ErrQueueClosed {
+		t.Errorf("Dequeue после Close: %v, ожидалось ErrQueueClosed", err)
+	}
+}
+
+func TestBlockingQueueDequeueTimeout(t *testing.T) {
+	queue := NewBlockingQueue[int](0)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := queue.DequeueWait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("DequeueWait: %v, ожидалось context.DeadlineExceeded", err)
+	}
+}
+
+func TestBlockingQueueDequeueCancel(t *testing.T) {
+	queue := NewBlockingQueue[int](0)
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error)
+	go func() {
+		_, err := queue.DequeueWait(ctx)
+		errs <- err
+	}()
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("DequeueWait: %v, ожидалось context.Canceled", err)
+	}
+
+	// Отменённое ожидание не должно мешать следующим операциям.
+	queue.Enqueue(1)
+	if value, err := queue.DequeueWait(context.Background()); err != nil || value != 1 {
+		t.Errorf("DequeueWait = %d, %v, ожидалось 1", value, err)
+	}
+}
+
+func TestBlockingQueueDequeueWaitsForValue(t *testing.T) {
+	queue := NewBlockingQueue[string](0)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		queue.Enqueue("a")
+	}()
+	if value, err := queue.DequeueWait(context.Background()); err != nil || value != "a" {
+		t.Errorf("DequeueWait = %q, %v, ожидалось \"a\"", value, err)
+	}
+}
+
+func TestBlockingQueueBackpressure(t *testing.T) {
+	queue := NewBlockingQueue[int](2)
+	queue.Enqueue(1)
+	queue.Enqueue(2)
+	if err := queue.Enqueue(3); err != ErrQueueFull {
+		t.Fatalf("Enqueue в заполненную очередь: %v, ожидалось ErrQueueFull", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := queue.EnqueueWait(ctx, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("EnqueueWait: %v, ожидалось context.DeadlineExceeded", err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		queue.Dequeue()
+	}()
+	if err := queue.EnqueueWait(context.Background(), 3); err != nil {
+		t.Fatalf("EnqueueWait: %v", err)
+	}
+	if queue.Len() != 2 {
+		t.Errorf("Len = %d, ожидалось 2", queue.Len())
+	}
+}
+
+func TestBlockingQueueCloseWakesWaiters(t *testing.T) {
+	queue := NewBlockingQueue[int](1)
+	queue.Enqueue(0)
+
+	const waiters = 4
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*waiters)
+	for i := 0; i < waiters; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- queue.EnqueueWait(context.Background(), 1)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	queue.Close()
+	wg.Wait()
+
+	// Оставшийся элемент можно извлечь и после закрытия.
+	if value, err := queue.DequeueWait(context.Background()); err != nil || value != 0 {
+		t.Fatalf("DequeueWait = %d, %v, ожидалось 0", value, err)
+	}
+	for i := 0; i < waiters; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := queue.DequeueWait(context.Background())
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != ErrQueueClosed {
+			t.Errorf("ожидание завершилось с %v, ожидалось ErrQueueClosed", err)
+		}
+	}
+	if err := queue.Enqueue(1); err != ErrQueueClosed {
+		t.Errorf("Enqueue после Close: %v, ожидалось ErrQueueClosed", err)
+	}
+}
+
+func TestBlockingQueueConcurrent(t *testing.T) {
+	queue := NewBlockingQueue[int](4)
+	const producers, items = 4, 500
+	var consumers sync.WaitGroup
+	results := make(chan int, producers*items)
+	for i := 0; i < producers; i++ {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				value, err := queue.DequeueWait(context.Background())
+				if err != nil {
+					return
+				}
+				results <- value
+			}
+		}()
+	}
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < items; i++ {
+				if err := queue.EnqueueWait(context.Background(), p*items+i); err != nil {
+					t.Errorf("EnqueueWait: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	queue.Close()
+	consumers.Wait()
+	close(results)
+
+	var got []int
+	for value := range results {
+		got = append(got, value)
+	}
+	checkExactlyOnce(t, got, producers*items)
+}
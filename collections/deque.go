@@ -0,0 +1,137 @@
+package collections
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Deque представляет двустороннюю очередь на двусвязном списке. Добавление
+// и извлечение с обоих концов выполняются за O(1).
+type Deque[T any] struct {
+	head *dequeNode[T]
+	tail *dequeNode[T]
+	size int
+}
+
+// dequeNode — узел двусвязного списка двусторонней очереди.
+type dequeNode[T any] struct {
+	data T
+	prev *dequeNode[T]
+	next *dequeNode[T]
+}
+
+// NewDeque создает новую пустую двустороннюю очередь.
+func NewDeque[T any]() *Deque[T] {
+	return &Deque[T]{}
+}
+
+// PushFront добавляет элемент в начало очереди.
+func (deque *Deque[T]) PushFront(value T) {
+	node := &dequeNode[T]{data: value, next: deque.head}
+	if deque.head == nil {
+		deque.tail = node
+	} else {
+		deque.head.prev = node
+	}
+	deque.head = node
+	deque.size++
+}
+
+// PushBack добавляет элемент в конец очереди.
+func (deque *Deque[T]) PushBack(value T) {
+	node := &dequeNode[T]{data: value, prev: deque.tail}
+	if deque.tail == nil {
+		deque.head = node
+	} else {
+		deque.tail.next = node
+	}
+	deque.tail = node
+	deque.size++
+}
+
+// PopFront извлекает элемент из начала очереди и возвращает его значение.
+func (deque *Deque[T]) PopFront() (T, error) {
+	if deque.head == nil {
+		var zero T
+		return zero, errors.New("двусторонняя очередь пуста")
+	}
+	node := deque.head
+	deque.head = node.next
+	if deque.head == nil {
+		deque.tail = nil
+	} else {
+		deque.head.prev = nil
+	}
+	deque.size--
+	return node.data, nil
+}
+
+// PopBack извлекает элемент из конца очереди и возвращает его значение.
+func (deque *Deque[T]) PopBack() (T, error) {
+	if deque.tail == nil {
+		var zero T
+		return zero, errors.New("двусторонняя очередь пуста")
+	}
+	node := deque.tail
+	deque.tail = node.prev
+	if deque.tail == nil {
+		deque.head = nil
+	} else {
+		deque.tail.next = nil
+	}
+	deque.size--
+	return node.data, nil
+}
+
+// PeekFront возвращает элемент из начала очереди, не извлекая его.
+func (deque *Deque[T]) PeekFront() (T, error) {
+	if deque.head == nil {
+		var zero T
+		return zero, errors.New("двусторонняя очередь пуста")
+	}
+	return deque.head.data, nil
+}
+
+// PeekBack возвращает элемент из конца очереди, не извлекая его.
+func (deque *Deque[T]) PeekBack() (T, error) {
+	if deque.tail == nil {
+		var zero T
+		return zero, errors.New("двусторонняя очередь пуста")
+	}
+	return deque.tail.data, nil
+}
+
+// Len возвращает количество элементов очереди.
+func (deque *Deque[T]) Len() int {
+	return deque.size
+}
+
+// Values возвращает элементы очереди от начала к концу.
+func (deque *Deque[T]) Values() []T {
+	values := make([]T, 0, deque.size)
+	for current := deque.head; current != nil; current = current.next {
+		values = append(values, current.data)
+	}
+	return values
+}
+
+// MarshalJSON представляет очередь JSON-массивом от начала к концу.
+func (deque *Deque[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(deque.Values())
+}
+
+// UnmarshalJSON заменяет содержимое очереди элементами JSON-массива;
+// первый элемент массива становится началом очереди.
+func (deque *Deque[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	deque.head = nil
+	deque.tail = nil
+	deque.size = 0
+	for _, value := range values {
+		deque.PushBack(value)
+	}
+	return nil
+}
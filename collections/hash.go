@@ -0,0 +1,25 @@
+package collections
+
+import "hash/maphash"
+
+// Hasher вычисляет хеш ключа хеш-таблицы. Индекс ячейки получается как
+// остаток от деления хеша на размер таблицы.
+type Hasher[K comparable] func(key K) uint64
+
+// HashString — полиномиальный хеш строки с основанием 31.
+func HashString(key string) uint64 {
+	var hashVal uint64
+	for i := 0; i < len(key); i++ {
+		hashVal = 31*hashVal + uint64(key[i])
+	}
+	return hashVal
+}
+
+// DefaultHasher возвращает хешер для любого сравнимого типа ключа,
+// основанный на hash/maphash.
+func DefaultHasher[K comparable]() Hasher[K] {
+	seed := maphash.MakeSeed()
+	return func(key K) uint64 {
+		return maphash.Comparable(seed, key)
+	}
+}
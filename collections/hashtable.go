@@ -0,0 +1,308 @@
+package collections
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// ErrTableFull возвращается, когда в хеш-таблице не осталось свободной
+// ячейки, а увеличить её не позволяет ограничение на размер.
+var ErrTableFull = errors.New("хеш-таблица заполнена")
+
+const (
+	// defaultTableSize — размер таблицы, если при создании указан
+	// неположительный размер.
+	defaultTableSize = 8
+	// maxLoadFactor — доля занятых ячеек (включая удалённые), при
+	// превышении которой таблица увеличивается вдвое.
+	maxLoadFactor = 0.75
+	// minLoadFactor — доля занятых ячеек, ниже которой таблица
+	// уменьшается вдвое.
+	minLoadFactor = 0.25
+)
+
+// Состояния ячейки хеш-таблицы.
+const (
+	slotEmpty uint8 = iota
+	slotOccupied
+	slotDeleted
+)
+
+// HashTable представляет хеш-таблицу с открытой адресацией. Коллизии
+// разрешаются линейным пробированием по кругу, удалённые записи помечаются
+// надгробиями, а размер таблицы меняется в зависимости от заполненности.
+// Занятость ячейки хранится отдельно от ключа, поэтому допустим любой ключ,
+// включая нулевое значение типа (например, пустую строку).
+type HashTable[K comparable, V any] struct {
+	data    []hashTableEntry[K, V]
+	hasher  Hasher[K]
+	count   int // число занятых ячеек
+	deleted int // число надгробий
+	minSize int
+	maxSize int // 0 — без ограничения
+	equal   func(a, b V) bool
+}
+
+type hashTableEntry[K comparable, V any] struct {
+	key   K
+	value V
+	state uint8
+}
+
+// NewHashTable создает новую хеш-таблицу из size ячеек. Таблица не
+// уменьшается меньше начального размера. Если hasher равен nil,
+// используется DefaultHasher.
+func NewHashTable[K comparable, V any](size int, hasher Hasher[K]) *HashTable[K, V] {
+	if size <= 0 {
+		size = defaultTableSize
+	}
+	if hasher == nil {
+		hasher = DefaultHasher[K]()
+	}
+	return &HashTable[K, V]{
+		data:    make([]hashTableEntry[K, V], size),
+		hasher:  hasher,
+		minSize: size,
+	}
+}
+
+// NewLike создает пустую хеш-таблицу с тем же хешером, начальным и
+// максимальным размером и функцией сравнения значений, что и ht.
+func (ht *HashTable[K, V]) NewLike() *HashTable[K, V] {
+	like := NewHashTable[K, V](ht.minSize, ht.hasher)
+	like.maxSize = ht.maxSize
+	like.equal = ht.equal
+	return like
+}
+
+// SetMaxSize ограничивает рост таблицы size ячейками. Значение 0 снимает
+// ограничение. Если ограничение меньше текущего размера, таблица не
+// уменьшается, но и не растёт дальше.
+func (ht *HashTable[K, V]) SetMaxSize(size int) {
+	ht.maxSize = size
+}
+
+// Len возвращает количество записей в хеш-таблице.
+func (ht *HashTable[K, V]) Len() int {
+	return ht.count
+}
+
+// Size возвращает текущее количество ячеек хеш-таблицы.
+func (ht *HashTable[K, V]) Size() int {
+	return len(ht.data)
+}
+
+// SetValueEqual задаёт функцию сравнения значений для CompareAndSwap.
+// По умолчанию используется reflect.DeepEqual.
+func (ht *HashTable[K, V]) SetValueEqual(equal func(a, b V) bool) {
+	ht.equal = equal
+}
+
+// Put добавляет пару ключ:значение в хеш-таблицу или обновляет значение
+// существующего ключа. Возвращает true, если ключ уже был в таблице.
+// Возвращает ErrTableFull, если свободной ячейки нет и таблица не может
+// вырасти.
+func (ht *HashTable[K, V]) Put(key K, value V) (bool, error) {
+	if slot := ht.find(key); slot >= 0 {
+		ht.data[slot].value = value
+		return true, nil
+	}
+	return false, ht.insert(key, value)
+}
+
+// PutIfAbsent добавляет пару ключ:значение, только если ключа ещё нет в
+// таблице. Возвращает значение, хранящееся по ключу после вызова, и true,
+// если запись была добавлена.
+func (ht *HashTable[K, V]) PutIfAbsent(key K, value V) (V, bool, error) {
+	if slot := ht.find(key); slot >= 0 {
+		return ht.data[slot].value, false, nil
+	}
+	if err := ht.insert(key, value); err != nil {
+		var zero V
+		return zero, false, err
+	}
+	return value, true, nil
+}
+
+// Replace заменяет значение существующего ключа. Возвращает прежнее
+// значение и true, если ключ был найден; иначе таблица не меняется.
+func (ht *HashTable[K, V]) Replace(key K, value V) (V, bool) {
+	slot := ht.find(key)
+	if slot < 0 {
+		var zero V
+		return zero, false
+	}
+	previous := ht.data[slot].value
+	ht.data[slot].value = value
+	return previous, true
+}
+
+// CompareAndSwap заменяет значение ключа на newValue, только если ключ
+// есть в таблице и его текущее значение равно old. Возвращает true, если
+// замена произошла.
+func (ht *HashTable[K, V]) CompareAndSwap(key K, old, newValue V) bool {
+	slot := ht.find(key)
+	if slot < 0 {
+		return false
+	}
+	equal := ht.equal
+	if equal == nil {
+		equal = func(a, b V) bool { return reflect.DeepEqual(a, b) }
+	}
+	if !equal(ht.data[slot].value, old) {
+		return false
+	}
+	ht.data[slot].value = newValue
+	return true
+}
+
+// Get возвращает значение по ключу из хеш-таблицы.
+func (ht *HashTable[K, V]) Get(key K) (V, bool) {
+	if slot := ht.find(key); slot >= 0 {
+		return ht.data[slot].value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete удаляет запись из хеш-таблицы по ключу. Ячейка помечается
+// надгробием, чтобы не разрывать цепочки пробирования других ключей.
+func (ht *HashTable[K, V]) Delete(key K) {
+	slot := ht.find(key)
+	if slot < 0 {
+		return
+	}
+	ht.data[slot] = hashTableEntry[K, V]{state: slotDeleted}
+	ht.count--
+	ht.deleted++
+
+	if len(ht.data) > ht.minSize && float64(ht.count) < minLoadFactor*float64(len(ht.data)) {
+		ht.resize(max(len(ht.data)/2, ht.minSize))
+	}
+}
+
+// Range вызывает fn для каждой записи хеш-таблицы в порядке расположения
+// в массиве. Обход прекращается, если fn возвращает false.
+func (ht *HashTable[K, V]) Range(fn func(key K, value V) bool) {
+	for _, entry := range ht.data {
+		if entry.state == slotOccupied {
+			if !fn(entry.key, entry.value) {
+				return
+			}
+		}
+	}
+}
+
+// index возвращает начальную ячейку для ключа.
+func (ht *HashTable[K, V]) index(key K) int {
+	return int(ht.hasher(key) % uint64(len(ht.data)))
+}
+
+// find возвращает номер ячейки с ключом key или -1, если ключа нет.
+// Поиск идёт по кругу и останавливается на первой пустой ячейке.
+func (ht *HashTable[K, V]) find(key K) int {
+	if len(ht.data) == 0 {
+		return -1
+	}
+	index := ht.index(key)
+	for i := 0; i < len(ht.data); i++ {
+		slot := (index + i) % len(ht.data)
+		switch {
+		case ht.data[slot].state == slotEmpty:
+			return -1
+		case ht.data[slot].state == slotOccupied && ht.data[slot].key == key:
+			return slot
+		}
+	}
+	return -1
+}
+
+// insert записывает новую пару в первую свободную ячейку цепочки ключа.
+// Вызывающий должен убедиться, что ключа ещё нет в таблице.
+func (ht *HashTable[K, V]) insert(key K, value V) error {
+	if ht.hasher == nil {
+		ht.hasher = DefaultHasher[K]()
+	}
+	ht.reserve()
+	index := ht.index(key)
+	for i := 0; i < len(ht.data); i++ {
+		slot := (index + i) % len(ht.data)
+		if ht.data[slot].state != slotOccupied {
+			if ht.data[slot].state == slotDeleted {
+				ht.deleted--
+			}
+			ht.data[slot] = hashTableEntry[K, V]{key: key, value: value, state: slotOccupied}
+			ht.count++
+			return nil
+		}
+	}
+	return ErrTableFull
+}
+
+// reserve готовит таблицу к вставке ещё одной записи: увеличивает её при
+// превышении maxLoadFactor или, если расти некуда, очищает надгробия.
+func (ht *HashTable[K, V]) reserve() {
+	if float64(ht.count+ht.deleted+1) <= maxLoadFactor*float64(len(ht.data)) {
+		return
+	}
+	newSize := max(len(ht.data)*2, defaultTableSize)
+	if ht.maxSize > 0 && newSize > ht.maxSize {
+		newSize = max(ht.maxSize, len(ht.data))
+	}
+	if newSize > len(ht.data) || ht.deleted > 0 {
+		ht.resize(newSize)
+	}
+}
+
+// resize перестраивает таблицу с новым количеством ячеек, удаляя надгробия.
+func (ht *HashTable[K, V]) resize(size int) {
+	old := ht.data
+	ht.data = make([]hashTableEntry[K, V], size)
+	ht.count = 0
+	ht.deleted = 0
+	for _, entry := range old {
+		if entry.state != slotOccupied {
+			continue
+		}
+		index := ht.index(entry.key)
+		for i := 0; i < size; i++ {
+			slot := (index + i) % size
+			if ht.data[slot].state == slotEmpty {
+				ht.data[slot] = entry
+				ht.count++
+				break
+			}
+		}
+	}
+}
+
+// MarshalJSON представляет хеш-таблицу JSON-объектом. Тип ключа должен
+// поддерживаться encoding/json в качестве ключа объекта: строка, целое число
+// или тип, реализующий encoding.TextMarshaler.
+func (ht *HashTable[K, V]) MarshalJSON() ([]byte, error) {
+	entries := make(map[K]V, ht.count)
+	ht.Range(func(key K, value V) bool {
+		entries[key] = value
+		return true
+	})
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON заменяет содержимое хеш-таблицы записями JSON-объекта.
+// Хешер и ограничения размера таблицы сохраняются.
+func (ht *HashTable[K, V]) UnmarshalJSON(data []byte) error {
+	var entries map[K]V
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	ht.data = make([]hashTableEntry[K, V], ht.minSize)
+	ht.count = 0
+	ht.deleted = 0
+	for key, value := range entries {
+		if err := ht.insert(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package collections
+
+import (
+	"errors"
+	"math/rand/v2"
+	"strconv"
+	"testing"
+)
+
+// constantHasher отправляет все ключи в последнюю ячейку таблицы из size
+// ячеек, так что цепочка пробирования сразу переходит через конец массива.
+func constantHasher(size int) Hasher[string] {
+	return func(string) uint64 { return uint64(size - 1) }
+}
+
+func TestHashTableWrapAroundProbing(t *testing.T) {
+	table := NewHashTable[string, int](8, constantHasher(8))
+	table.SetMaxSize(8)
+	keys := []string{"a", "b", "c", "d", "e"}
+	for i, key := range keys {
+		if _, err := table.Put(key, i); err != nil {
+			t.Fatalf("Put(%q): %v", key, err)
+		}
+	}
+	for i, key := range keys {
+		if value, ok := table.Get(key); !ok || value != i {
+			t.Errorf("Get(%q) = %d, %t, ожидалось %d, true", key, value, ok, i)
+		}
+	}
+
+	// Удаление из середины цепочки не должно разрывать её.
+	table.Delete("b")
+	if _, ok := table.Get("b"); ok {
+		t.Error("удалённый ключ найден")
+	}
+	for _, key := range []string{"c", "d", "e"} {
+		if _, ok := table.Get(key); !ok {
+			t.Errorf("после удаления b не найден ключ %q", key)
+		}
+	}
+}
+
+func TestHashTableTombstoneReuse(t *testing.T) {
+	table := NewHashTable[string, int](8, constantHasher(8))
+	table.SetMaxSize(8)
+	for _, key := range []string{"a", "b", "c"} {
+		table.Put(key, 0)
+	}
+
+	// Многократные добавления и удаления оставляют надгробия; таблица,
+	// которой некуда расти, должна переиспользовать их ячейки.
+	for i := 0; i < 100; i++ {
+		key := "tmp" + strconv.Itoa(i)
+		if _, err := table.Put(key, i); err != nil {
+			t.Fatalf("Put(%q) на шаге %d: %v", key, i, err)
+		}
+		table.Delete(key)
+	}
+	if table.Len() != 3 || table.Size() != 8 {
+		t.Errorf("Len = %d, Size = %d, ожидалось 3 и 8", table.Len(), table.Size())
+	}
+	for _, key := range []string{"a", "b", "c"} {
+		if _, ok := table.Get(key); !ok {
+			t.Errorf("не найден ключ %q", key)
+		}
+	}
+}
+
+func TestHashTableGrowAndShrink(t *testing.T) {
+	table := NewHashTable[string, int](8, nil)
+	for i := 0; i < 100; i++ {
+		table.Put(strconv.Itoa(i), i)
+	}
+	if table.Size() <= 8 {
+		t.Fatalf("Size = %d: таблица не выросла", table.Size())
+	}
+	if float64(table.Len()) > maxLoadFactor*float64(table.Size()) {
+		t.Errorf("заполненность %d/%d выше maxLoadFactor", table.Len(), table.Size())
+	}
+	for i := 0; i < 100; i++ {
+		if value, ok := table.Get(strconv.Itoa(i)); !ok || value != i {
+			t.Fatalf("Get(%d) = %d, %t", i, value, ok)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		table.Delete(strconv.Itoa(i))
+	}
+	if table.Len() != 0 || table.Size() != 8 {
+		t.Errorf("после удаления всех ключей Len = %d, Size = %d, ожидалось 0 и 8", table.Len(), table.Size())
+	}
+}
+
+func TestHashTableMaxSize(t *testing.T) {
+	table := NewHashTable[string, int](4, nil)
+	table.SetMaxSize(8)
+	for i := 0; i < 8; i++ {
+		if _, err := table.Put(strconv.Itoa(i), i); err != nil {
+			t.Fatalf("Put(%d): %v", i, err)
+		}
+	}
+	if table.Size() != 8 {
+		t.Errorf("Size = %d, ожидалось 8", table.Size())
+	}
+	if _, err := table.Put("extra", 0); !errors.Is(err, ErrTableFull) {
+		t.Errorf("Put в заполненную таблицу: %v, ожидалось ErrTableFull", err)
+	}
+
+	// Обновление существующего ключа не требует новой ячейки.
+	if updated, err := table.Put("0", 100); err != nil || !updated {
+		t.Errorf("Put существующего ключа = %t, %v", updated, err)
+	}
+	if table.Len() != 8 {
+		t.Errorf("Len = %d, ожидалось 8", table.Len())
+	}
+}
+
+func TestHashTableMatchesMap(t *testing.T) {
+	hashers := map[string]Hasher[string]{
+		"DefaultHasher": nil,
+		"HashString":    HashString,
+		// Все ключи в одной цепочке — худший случай для пробирования.
+		"constant": func(string) uint64 { return 0 },
+	}
+	for name, hasher := range hashers {
+		t.Run(name, func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(1, 2))
+			table := NewHashTable[string, int](4, hasher)
+			want := make(map[string]int)
+			for i := 0; i < 5000; i++ {
+				key := strconv.Itoa(rng.IntN(64))
+				switch rng.IntN(3) {
+				case 0, 1:
+					_, existed := want[key]
+					updated, err := table.Put(key, i)
+					if err != nil || updated != existed {
+						t.Fatalf("шаг %d: Put(%q) = %t, %v, ожидалось %t", i, key, updated, err, existed)
+					}
+					want[key] = i
+				case 2:
+					table.Delete(key)
+					delete(want, key)
+				}
+				if table.Len() != len(want) {
+					t.Fatalf("шаг %d: Len = %d, ожидалось %d", i, table.Len(), len(want))
+				}
+			}
+
+			for key, value := range want {
+				if got, ok := table.Get(key); !ok || got != value {
+					t.Errorf("Get(%q) = %d, %t, ожидалось %d", key, got, ok, value)
+				}
+			}
+			seen := 0
+			table.Range(func(key string, value int) bool {
+				seen++
+				if want[key] != value {
+					t.Errorf("Range: %q = %d, ожидалось %d", key, value, want[key])
+				}
+				return true
+			})
+			if seen != len(want) {
+				t.Errorf("Range обошёл %d записей, ожидалось %d", seen, len(want))
+			}
+		})
+	}
+}
+
+func TestHashTableConditionalUpdates(t *testing.T) {
+	tests := []struct {
+		name      string
+		op        func(table *HashTable[string, int]) (int, bool)
+		wantValue int
+		wantOK    bool
+		wantAfter int // значение ключа "a" после операции
+	}{
+		{"PutIfAbsent существующего", func(table *HashTable[string, int]) (int, bool) {
+			value, inserted, _ := table.PutIfAbsent("a", 2)
+			return value, inserted
+		}, 1, false, 1},
+		{"PutIfAbsent нового", func(table *HashTable[string, int]) (int, bool) {
+			value, inserted, _ := table.PutIfAbsent("b", 2)
+			return value, inserted
+		}, 2, true, 1},
+		{"Replace существующего", func(table *HashTable[string, int]) (int, bool) {
+			return table.Replace("a", 2)
+		}, 1, true, 2},
+		{"Replace отсутствующего", func(table *HashTable[string, int]) (int, bool) {
+			return table.Replace("b", 2)
+		}, 0, false, 1},
+		{"CompareAndSwap совпадает", func(table *HashTable[string, int]) (int, bool) {
+			return 0, table.CompareAndSwap("a", 1, 2)
+		}, 0, true, 2},
+		{"CompareAndSwap не совпадает", func(table *HashTable[string, int]) (int, bool) {
+			return 0, table.CompareAndSwap("a", 5, 2)
+		}, 0, false, 1},
+		{"CompareAndSwap отсутствующего", func(table *HashTable[string, int]) (int, bool) {
+			return 0, table.CompareAndSwap("b", 0, 2)
+		}, 0, false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewHashTable[string, int](8, nil)
+			table.Put("a", 1)
+			value, ok := tt.op(table)
+			if value != tt.wantValue || ok != tt.wantOK {
+				t.Errorf("результат = %d, %t, ожидалось %d, %t", value, ok, tt.wantValue, tt.wantOK)
+			}
+			if got, _ := table.Get("a"); got != tt.wantAfter {
+				t.Errorf("значение a = %d, ожидалось %d", got, tt.wantAfter)
+			}
+			if _, found := table.Get("b"); found != (tt.name == "PutIfAbsent нового") {
+				t.Errorf("наличие b = %t", found)
+			}
+		})
+	}
+}
+
+func TestHashTablePutIfAbsentFull(t *testing.T) {
+	table := NewHashTable[string, int](4, nil)
+	table.SetMaxSize(4)
+	for i := 0; i < 4; i++ {
+		table.Put(strconv.Itoa(i), i)
+	}
+	if _, inserted, err := table.PutIfAbsent("extra", 0); !errors.Is(err, ErrTableFull) || inserted {
+		t.Errorf("PutIfAbsent = %t, %v, ожидалось false, ErrTableFull", inserted, err)
+	}
+	if value, inserted, err := table.PutIfAbsent("0", 5); err != nil || inserted || value != 0 {
+		t.Errorf("PutIfAbsent существующего = %d, %t, %v, ожидалось 0, false, nil", value, inserted, err)
+	}
+}
+
+func TestHashTableValueEqual(t *testing.T) {
+	table := NewHashTable[string, []int](8, nil)
+	table.Put("a", []int{1, 2})
+	// По умолчанию значения сравниваются reflect.DeepEqual.
+	if !table.CompareAndSwap("a", []int{1, 2}, []int{3}) {
+		t.Error("CompareAndSwap с равным срезом не сработал")
+	}
+	table.SetValueEqual(func(a, b []int) bool { return len(a) == len(b) })
+	if !table.CompareAndSwap("a", []int{9}, nil) {
+		t.Error("CompareAndSwap не использовал SetValueEqual")
+	}
+}
+
+func TestHashTableZeroKey(t *testing.T) {
+	byString := NewHashTable[string, string](8, HashString)
+	if updated, err := byString.Put("", "empty"); err != nil || updated {
+		t.Fatalf("Put(\"\") = %t, %v", updated, err)
+	}
+	if value, ok := byString.Get(""); !ok || value != "empty" {
+		t.Errorf("Get(\"\") = %q, %t", value, ok)
+	}
+	if byString.Len() != 1 {
+		t.Errorf("Len = %d, ожидалось 1", byString.Len())
+	}
+	byString.Delete("")
+	if _, ok := byString.Get(""); ok || byString.Len() != 0 {
+		t.Errorf("после Delete ключ найден или Len = %d", byString.Len())
+	}
+
+	// Нулевой ключ не должен путаться с пустыми ячейками после роста.
+	ints := NewHashTable[int, int](4, nil)
+	for i := 0; i < 20; i++ {
+		ints.Put(i, i*10)
+	}
+	if value, ok := ints.Get(0); !ok || value != 0 {
+		t.Errorf("Get(0) = %d, %t", value, ok)
+	}
+	if _, ok := ints.Get(-1); ok {
+		t.Error("найден ключ, которого нет в таблице")
+	}
+}
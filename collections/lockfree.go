@@ -0,0 +1,164 @@
+package collections
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// Неблокирующие стек и очередь построены на сравнении с обменом
+// (sync/atomic) и не используют мьютексов. Классическая для таких структур
+// проблема ABA (узел удалён, освобождён и снова вставлен по тому же адресу
+// между чтением и CompareAndSwap) здесь не возникает: каждый Push и Enqueue
+// создаёт новый узел, а сборщик мусора не освобождает узел, пока на него
+// ссылается хотя бы одна горутина. Поэтому совпадение указателя означает,
+// что это тот же самый узел.
+
+// atomicNode — узел списка со ссылкой на следующий узел, которую можно
+// читать и менять атомарно.
+type atomicNode[T any] struct {
+	data T
+	next atomic.Pointer[atomicNode[T]]
+}
+
+// LockFreeStack — неблокирующий стек Трайбера. Его можно использовать из
+// нескольких горутин без дополнительной синхронизации.
+type LockFreeStack[T any] struct {
+	head atomic.Pointer[atomicNode[T]]
+	size atomic.Int64
+}
+
+// NewLockFreeStack создает новый пустой неблокирующий стек.
+func NewLockFreeStack[T any]() *LockFreeStack[T] {
+	return &LockFreeStack[T]{}
+}
+
+// Push добавляет элемент на вершину стека.
+func (stack *LockFreeStack[T]) Push(value T) {
+	node := &atomicNode[T]{data: value}
+	for {
+		head := stack.head.Load()
+		node.next.Store(head)
+		if stack.head.CompareAndSwap(head, node) {
+			stack.size.Add(1)
+			return
+		}
+	}
+}
+
+// Pop извлекает элемент с вершины стека и возвращает его значение.
+func (stack *LockFreeStack[T]) Pop() (T, error) {
+	for {
+		head := stack.head.Load()
+		if head == nil {
+			var zero T
+			return zero, errors.New("стек пуст")
+		}
+		if stack.head.CompareAndSwap(head, head.next.Load()) {
+			stack.size.Add(-1)
+			return head.data, nil
+		}
+	}
+}
+
+// Len возвращает количество элементов стека. При одновременных изменениях
+// значение может отставать от фактического: счётчик меняется после
+// успешного CompareAndSwap, и извлечение может учесться раньше добавления.
+// Отрицательное значение в этом случае заменяется нулём.
+func (stack *LockFreeStack[T]) Len() int {
+	return max(int(stack.size.Load()), 0)
+}
+
+// LockFreeQueue — неблокирующая очередь Майкла–Скотта. Список всегда
+// начинается с фиктивного узла; первый элемент очереди хранится в узле,
+// следующем за ним. У нулевого значения фиктивный узел создаётся при первой
+// операции, поэтому оно готово к работе.
+type LockFreeQueue[T any] struct {
+	head atomic.Pointer[atomicNode[T]]
+	tail atomic.Pointer[atomicNode[T]]
+	size atomic.Int64
+}
+
+// NewLockFreeQueue создает новую пустую неблокирующую очередь.
+func NewLockFreeQueue[T any]() *LockFreeQueue[T] {
+	queue := &LockFreeQueue[T]{}
+	queue.init()
+	return queue
+}
+
+// init создаёт фиктивный узел, если его ещё нет. Пока хвост не задан, ни
+// одна операция не могла сдвинуть голову, поэтому хвост можно указать на
+// текущую голову даже при гонке нескольких горутин.
+func (queue *LockFreeQueue[T]) init() {
+	if queue.tail.Load() != nil {
+		return
+	}
+	head := queue.head.Load()
+	if head == nil {
+		dummy := &atomicNode[T]{}
+		if queue.head.CompareAndSwap(nil, dummy) {
+			head = dummy
+		} else {
+			head = queue.head.Load()
+		}
+	}
+	queue.tail.CompareAndSwap(nil, head)
+}
+
+// Enqueue добавляет элемент в конец очереди.
+func (queue *LockFreeQueue[T]) Enqueue(value T) {
+	queue.init()
+	node := &atomicNode[T]{data: value}
+	for {
+		tail := queue.tail.Load()
+		next := tail.next.Load()
+		if tail != queue.tail.Load() {
+			continue
+		}
+		if next != nil {
+			// Другая горутина уже добавила узел, но не успела сдвинуть
+			// хвост; помогаем ей и повторяем попытку.
+			queue.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, node) {
+			queue.tail.CompareAndSwap(tail, node)
+			queue.size.Add(1)
+			return
+		}
+	}
+}
+
+// Dequeue извлекает элемент из начала очереди и возвращает его значение.
+func (queue *LockFreeQueue[T]) Dequeue() (T, error) {
+	queue.init()
+	for {
+		head := queue.head.Load()
+		tail := queue.tail.Load()
+		next := head.next.Load()
+		if head != queue.head.Load() {
+			continue
+		}
+		if next == nil {
+			var zero T
+			return zero, errors.New("очередь пуста")
+		}
+		if head == tail {
+			// Хвост отстал от добавленного узла.
+			queue.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		value := next.data
+		if queue.head.CompareAndSwap(head, next) {
+			// next становится новым фиктивным узлом.
+			queue.size.Add(-1)
+			return value, nil
+		}
+	}
+}
+
+// Len возвращает количество элементов очереди. При одновременных
+// изменениях значение может отставать от фактического (см.
+// LockFreeStack.Len).
+func (queue *LockFreeQueue[T]) Len() int {
+	return max(int(queue.size.Load()), 0)
+}
@@ -0,0 +1,155 @@
+package collections
+
+import (
+	"sync"
+	"testing"
+)
+
+const (
+	lockFreeProducers = 8
+	lockFreeItems     = 5000 // элементов на одного производителя
+)
+
+// checkExactlyOnce проверяет, что каждый из элементов 0..n-1 получен ровно
+// один раз.
+func checkExactlyOnce(t *testing.T, got []int, n int) {
+	t.Helper()
+	seen := make([]int, n)
+	for _, value := range got {
+		seen[value]++
+	}
+	for value, count := range seen {
+		if count != 1 {
+			t.Fatalf("элемент %d получен %d раз", value, count)
+		}
+	}
+}
+
+// consume извлекает элементы функцией pop, пока не получит n элементов.
+func consume(pop func() (int, error), n int, done *sync.WaitGroup, results chan<- []int) {
+	defer done.Done()
+	var got []int
+	for len(got) < n {
+		if value, err := pop(); err == nil {
+			got = append(got, value)
+		}
+	}
+	results <- got
+}
+
+// runProducersConsumers одновременно добавляет и извлекает элементы и
+// возвращает все извлечённые значения.
+func runProducersConsumers(push func(int), pop func() (int, error)) []int {
+	var wg sync.WaitGroup
+	results := make(chan []int, lockFreeProducers)
+	for p := 0; p < lockFreeProducers; p++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < lockFreeItems; i++ {
+				push(p*lockFreeItems + i)
+			}
+		}()
+		go consume(pop, lockFreeItems, &wg, results)
+	}
+	wg.Wait()
+	close(results)
+
+	var all []int
+	for got := range results {
+		all = append(all, got...)
+	}
+	return all
+}
+
+func TestLockFreeStackConcurrent(t *testing.T) {
+	stack := NewLockFreeStack[int]()
+	got := runProducersConsumers(stack.Push, stack.Pop)
+	checkExactlyOnce(t, got, lockFreeProducers*lockFreeItems)
+	if stack.Len() != 0 {
+		t.Errorf("Len = %d, ожидалось 0", stack.Len())
+	}
+	if _, err := stack.Pop(); err == nil {
+		t.Error("Pop из пустого стека не вернул ошибку")
+	}
+}
+
+func TestLockFreeQueueConcurrent(t *testing.T) {
+	queue := NewLockFreeQueue[int]()
+	got := runProducersConsumers(queue.Enqueue, queue.Dequeue)
+	checkExactlyOnce(t, got, lockFreeProducers*lockFreeItems)
+	if queue.Len() != 0 {
+		t.Errorf("Len = %d, ожидалось 0", queue.Len())
+	}
+	if _, err := queue.Dequeue(); err == nil {
+		t.Error("Dequeue из пустой очереди не вернул ошибку")
+	}
+}
+
+func TestLockFreeQueueOrder(t *testing.T) {
+	queue := NewLockFreeQueue[int]()
+	for i := 0; i < 10; i++ {
+		queue.Enqueue(i)
+	}
+	for i := 0; i < 10; i++ {
+		if value, err := queue.Dequeue(); err != nil || value != i {
+			t.Fatalf("Dequeue = %d, %v, ожидалось %d", value, err, i)
+		}
+	}
+}
+
+func BenchmarkLockFreeStack(b *testing.B) {
+	stack := NewLockFreeStack[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			stack.Push(1)
+			stack.Pop()
+		}
+	})
+}
+
+func BenchmarkSyncStack(b *testing.B) {
+	stack := NewSyncStack[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			stack.Push(1)
+			stack.Pop()
+		}
+	})
+}
+
+func BenchmarkLockFreeQueue(b *testing.B) {
+	queue := NewLockFreeQueue[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			queue.Enqueue(1)
+			queue.Dequeue()
+		}
+	})
+}
+
+func BenchmarkSyncQueue(b *testing.B) {
+	queue := NewSyncQueue[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			queue.Enqueue(1)
+			queue.Dequeue()
+		}
+	})
+}
+
+func TestLockFreeQueueZeroValue(t *testing.T) {
+	var queue LockFreeQueue[int]
+	if _, err := queue.Dequeue(); err == nil {
+		t.Error("Dequeue из пустой очереди не вернул ошибку")
+	}
+	queue.Enqueue(1)
+	if value, err := queue.Dequeue(); err != nil || value != 1 {
+		t.Errorf("Dequeue = %d, %v, ожидалось 1", value, err)
+	}
+
+	// Первое использование из нескольких горутин одновременно.
+	var concurrent LockFreeQueue[int]
+	got := runProducersConsumers(concurrent.Enqueue, concurrent.Dequeue)
+	checkExactlyOnce(t, got, lockFreeProducers*lockFreeItems)
+}
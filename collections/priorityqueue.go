@@ -0,0 +1,148 @@
+package collections
+
+import (
+	"cmp"
+	"container/heap"
+	"encoding/json"
+	"errors"
+	"slices"
+)
+
+// PriorityItem — элемент очереди с приоритетом вместе с его приоритетом.
+type PriorityItem[T any] struct {
+	Value    T   `json:"value"`
+	Priority int `json:"priority"`
+}
+
+// PriorityQueue представляет очередь с приоритетом на двоичной куче. Первым
+// извлекается элемент с наибольшим приоритетом; элементы с равным
+// приоритетом извлекаются в порядке добавления.
+type PriorityQueue[T comparable] struct {
+	heap priorityHeap[T]
+	// seq — номер следующего добавляемого элемента, по нему упорядочиваются
+	// элементы с равным приоритетом.
+	seq uint64
+}
+
+// priorityEntry — элемент кучи.
+type priorityEntry[T comparable] struct {
+	value    T
+	priority int
+	seq      uint64
+}
+
+// priorityHeap реализует heap.Interface для PriorityQueue.
+type priorityHeap[T comparable] []priorityEntry[T]
+
+func (h priorityHeap[T]) Len() int { return len(h) }
+
+func (h priorityHeap[T]) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h priorityHeap[T]) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *priorityHeap[T]) Push(x any) { *h = append(*h, x.(priorityEntry[T])) }
+
+func (h *priorityHeap[T]) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	old[len(old)-1] = priorityEntry[T]{}
+	*h = old[:len(old)-1]
+	return entry
+}
+
+// NewPriorityQueue создает новую пустую очередь с приоритетом.
+func NewPriorityQueue[T comparable]() *PriorityQueue[T] {
+	return &PriorityQueue[T]{}
+}
+
+// Push добавляет элемент с указанным приоритетом.
+func (pq *PriorityQueue[T]) Push(value T, priority int) {
+	heap.Push(&pq.heap, priorityEntry[T]{value: value, priority: priority, seq: pq.seq})
+	pq.seq++
+}
+
+// Pop извлекает элемент с наибольшим приоритетом и возвращает его значение.
+func (pq *PriorityQueue[T]) Pop() (T, error) {
+	if len(pq.heap) == 0 {
+		var zero T
+		return zero, errors.New("очередь с приоритетом пуста")
+	}
+	return heap.Pop(&pq.heap).(priorityEntry[T]).value, nil
+}
+
+// Peek возвращает элемент с наибольшим приоритетом, не извлекая его.
+func (pq *PriorityQueue[T]) Peek() (T, error) {
+	if len(pq.heap) == 0 {
+		var zero T
+		return zero, errors.New("очередь с приоритетом пуста")
+	}
+	return pq.heap[0].value, nil
+}
+
+// UpdatePriority меняет приоритет элемента value. Если одинаковых
+// элементов несколько, меняется тот, что извлекался бы первым: этот выбор
+// зависит только от порядка извлечения и поэтому не меняется после
+// сохранения и загрузки очереди. Среди элементов с равным приоритетом
+// элемент сохраняет своё место по порядку добавления. Возвращает false, если
+// элемента нет в очереди. Поиск элемента занимает O(n).
+func (pq *PriorityQueue[T]) UpdatePriority(value T, priority int) bool {
+	found := -1
+	for i, entry := range pq.heap {
+		if entry.value == value && (found < 0 || pq.heap.Less(i, found)) {
+			found = i
+		}
+	}
+	if found < 0 {
+		return false
+	}
+	pq.heap[found].priority = priority
+	heap.Fix(&pq.heap, found)
+	return true
+}
+
+// Len возвращает количество элементов очереди.
+func (pq *PriorityQueue[T]) Len() int {
+	return len(pq.heap)
+}
+
+// Items возвращает элементы с приоритетами в порядке извлечения.
+func (pq *PriorityQueue[T]) Items() []PriorityItem[T] {
+	entries := slices.Clone(pq.heap)
+	slices.SortFunc(entries, func(a, b priorityEntry[T]) int {
+		if c := cmp.Compare(b.priority, a.priority); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.seq, b.seq)
+	})
+	items := make([]PriorityItem[T], len(entries))
+	for i, entry := range entries {
+		items[i] = PriorityItem[T]{Value: entry.value, Priority: entry.priority}
+	}
+	return items
+}
+
+// MarshalJSON представляет очередь JSON-массивом объектов
+// {"value": ..., "priority": ...} в порядке извлечения.
+func (pq *PriorityQueue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pq.Items())
+}
+
+// UnmarshalJSON заменяет содержимое очереди элементами JSON-массива.
+// Элементы с равным приоритетом извлекаются в порядке массива.
+func (pq *PriorityQueue[T]) UnmarshalJSON(data []byte) error {
+	var items []PriorityItem[T]
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	pq.heap = nil
+	pq.seq = 0
+	for _, item := range items {
+		pq.Push(item.Value, item.Priority)
+	}
+	return nil
+}
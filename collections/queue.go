@@ -0,0 +1,85 @@
+package collections
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Queue представляет очередь.
+type Queue[T any] struct {
+	head *Node[T]
+	tail *Node[T]
+	size int
+}
+
+// NewQueue создает новую пустую очередь.
+func NewQueue[T any]() *Queue[T] {
+	return &Queue[T]{}
+}
+
+// Enqueue добавляет элемент в конец очереди.
+func (queue *Queue[T]) Enqueue(value T) {
+	node := &Node[T]{data: value}
+	if queue.head == nil {
+		queue.head = node
+		queue.tail = node
+	} else {
+		queue.tail.next = node
+		queue.tail = node
+	}
+	queue.size++
+}
+
+// Dequeue извлекает элемент из начала очереди и возвращает его значение.
+func (queue *Queue[T]) Dequeue() (T, error) {
+	if queue.head == nil {
+		var zero T
+		return zero, errors.New("очередь пуста")
+	}
+	value := queue.head.data
+	queue.head = queue.head.next
+	queue.size--
+	// Если после извлечения элемента очередь осталась пустой, обновляем указатель на хвост.
+	if queue.head == nil {
+		queue.tail = nil
+	}
+	return value, nil
+}
+
+// Len возвращает количество элементов очереди.
+func (queue *Queue[T]) Len() int {
+	return queue.size
+}
+
+// Values возвращает элементы очереди, начиная с головы.
+func (queue *Queue[T]) Values() []T {
+	var values []T
+	for current := queue.head; current != nil; current = current.next {
+		values = append(values, current.data)
+	}
+	return values
+}
+
+// MarshalJSON представляет очередь JSON-массивом, начиная с головы.
+func (queue *Queue[T]) MarshalJSON() ([]byte, error) {
+	values := queue.Values()
+	if values == nil {
+		values = []T{}
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON заменяет содержимое очереди элементами JSON-массива; первый
+// элемент массива становится головой.
+func (queue *Queue[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	queue.head, queue.tail = nil, nil
+	queue.size = 0
+	for _, value := range values {
+		queue.Enqueue(value)
+	}
+	return nil
+}
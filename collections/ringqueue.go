@@ -0,0 +1,144 @@
+package collections
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// OverflowPolicy определяет, что делает RingQueue фиксированной ёмкости при
+// добавлении в заполненную очередь.
+type OverflowPolicy int
+
+const (
+	// OverwriteOldest вытесняет самый старый элемент очереди.
+	OverwriteOldest OverflowPolicy = iota
+	// RejectNew отбрасывает добавляемый элемент.
+	RejectNew
+)
+
+// defaultRingSize — начальный размер буфера растущей RingQueue.
+const defaultRingSize = 8
+
+// RingQueue представляет очередь на кольцевом буфере. В отличие от Queue,
+// она не выделяет память под каждый элемент: буфер удваивается только при
+// заполнении, поэтому добавление выполняется за амортизированное O(1).
+// Очередь, созданная NewBoundedRingQueue, не растёт, а при переполнении
+// поступает согласно OverflowPolicy.
+type RingQueue[T any] struct {
+	buf      []T
+	head     int // индекс первого элемента в buf
+	size     int
+	bounded  bool
+	overflow OverflowPolicy
+}
+
+// NewRingQueue создает новую пустую очередь на кольцевом буфере без
+// ограничения ёмкости.
+func NewRingQueue[T any]() *RingQueue[T] {
+	return &RingQueue[T]{}
+}
+
+// NewBoundedRingQueue создает новую пустую очередь ёмкостью capacity
+// элементов с политикой переполнения overflow. Если capacity не больше
+// нуля, используется ёмкость 1.
+func NewBoundedRingQueue[T any](capacity int, overflow OverflowPolicy) *RingQueue[T] {
+	return &RingQueue[T]{
+		buf:      make([]T, max(capacity, 1)),
+		bounded:  true,
+		overflow: overflow,
+	}
+}
+
+// Enqueue добавляет элемент в конец очереди. Если очередь фиксированной
+// ёмкости заполнена, при OverwriteOldest вытесняется первый элемент, а при
+// RejectNew добавляемый элемент отбрасывается; узнать об этом позволяет
+// TryEnqueue.
+func (queue *RingQueue[T]) Enqueue(value T) {
+	queue.TryEnqueue(value)
+}
+
+// TryEnqueue добавляет элемент в конец очереди так же, как Enqueue, но
+// возвращает ErrQueueFull, если элемент отброшен по политике RejectNew.
+func (queue *RingQueue[T]) TryEnqueue(value T) error {
+	if queue.size == len(queue.buf) {
+		switch {
+		case !queue.bounded:
+			queue.grow()
+		case queue.overflow == RejectNew:
+			return ErrQueueFull
+		default:
+			// Новый элемент занимает место самого старого.
+			queue.buf[queue.head] = value
+			queue.head = (queue.head + 1) % len(queue.buf)
+			return nil
+		}
+	}
+	queue.buf[(queue.head+queue.size)%len(queue.buf)] = value
+	queue.size++
+	return nil
+}
+
+// Dequeue извлекает элемент из начала очереди и возвращает его значение.
+func (queue *RingQueue[T]) Dequeue() (T, error) {
+	var zero T
+	if queue.size == 0 {
+		return zero, errors.New("очередь пуста")
+	}
+	value := queue.buf[queue.head]
+	// Обнуляем ячейку, чтобы буфер не удерживал значение от сборщика мусора.
+	queue.buf[queue.head] = zero
+	queue.head = (queue.head + 1) % len(queue.buf)
+	queue.size--
+	return value, nil
+}
+
+// Len возвращает количество элементов очереди.
+func (queue *RingQueue[T]) Len() int {
+	return queue.size
+}
+
+// Cap возвращает текущий размер буфера. Для очереди фиксированной ёмкости
+// это её ёмкость.
+func (queue *RingQueue[T]) Cap() int {
+	return len(queue.buf)
+}
+
+// Values возвращает элементы очереди от начала к концу.
+func (queue *RingQueue[T]) Values() []T {
+	values := make([]T, queue.size)
+	for i := range values {
+		values[i] = queue.buf[(queue.head+i)%len(queue.buf)]
+	}
+	return values
+}
+
+// grow удваивает буфер, переставляя элементы в его начало.
+func (queue *RingQueue[T]) grow() {
+	buf := make([]T, max(2*len(queue.buf), defaultRingSize))
+	n := copy(buf, queue.buf[queue.head:])
+	copy(buf[n:], queue.buf[:queue.head])
+	queue.buf = buf
+	queue.head = 0
+}
+
+// MarshalJSON представляет очередь JSON-массивом от начала к концу.
+func (queue *RingQueue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(queue.Values())
+}
+
+// UnmarshalJSON заменяет содержимое очереди элементами JSON-массива.
+// Ёмкость и политика переполнения сохраняются, поэтому в очередь
+// фиксированной ёмкости попадают элементы согласно этой политике.
+func (queue *RingQueue[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	clear(queue.buf)
+	queue.head = 0
+	queue.size = 0
+	for _, value := range values {
+		queue.Enqueue(value)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package collections
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestRingQueueWrapAndGrow(t *testing.T) {
+	queue := NewRingQueue[int]()
+	var want []int
+	// Чередуем добавление и извлечение, чтобы голова уходила по кругу, а
+	// буфер рос, когда элементы переходят через его конец.
+	next := 0
+	for round := 0; round < 10; round++ {
+		for i := 0; i < 5; i++ {
+			queue.Enqueue(next)
+			want = append(want, next)
+			next++
+		}
+		for i := 0; i < 3; i++ {
+			value, err := queue.Dequeue()
+			if err != nil || value != want[0] {
+				t.Fatalf("Dequeue = %d, %v, ожидалось %d", value, err, want[0])
+			}
+			want = want[1:]
+		}
+		if got := queue.Values(); !slices.Equal(got, want) {
+			t.Fatalf("Values = %v, ожидалось %v", got, want)
+		}
+	}
+	if queue.Len() != len(want) {
+		t.Errorf("Len = %d, ожидалось %d", queue.Len(), len(want))
+	}
+	if queue.Cap() < queue.Len() {
+		t.Errorf("Cap = %d меньше Len = %d", queue.Cap(), queue.Len())
+	}
+}
+
+func TestRingQueueEmpty(t *testing.T) {
+	var queue RingQueue[int]
+	if _, err := queue.Dequeue(); err == nil {
+		t.Error("Dequeue из пустой очереди не вернул ошибку")
+	}
+	data, err := json.Marshal(&queue)
+	if err != nil || string(data) != "[]" {
+		t.Errorf("MarshalJSON = %s, %v, ожидалось []", data, err)
+	}
+}
+
+func TestRingQueueOverflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   OverflowPolicy
+		enqueue  []int
+		want     []int
+		rejected int
+	}{
+		{"вытеснение без переполнения", OverwriteOldest, []int{1, 2}, []int{1, 2}, 0},
+		{"вытеснение старых", OverwriteOldest, []int{1, 2, 3, 4, 5}, []int{3, 4, 5}, 0},
+		{"вытеснение по кругу", OverwriteOldest, []int{1, 2, 3, 4, 5, 6, 7}, []int{5, 6, 7}, 0},
+		{"отказ без переполнения", RejectNew, []int{1, 2, 3}, []int{1, 2, 3}, 0},
+		{"отказ новых", RejectNew, []int{1, 2, 3, 4, 5}, []int{1, 2, 3}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue := NewBoundedRingQueue[int](3, tt.policy)
+			rejected := 0
+			for _, value := range tt.enqueue {
+				if err := queue.TryEnqueue(value); err == ErrQueueFull {
+					rejected++
+				} else if err != nil {
+					t.Fatalf("TryEnqueue: %v", err)
+				}
+			}
+			if got := queue.Values(); !slices.Equal(got, tt.want) {
+				t.Errorf("Values = %v, ожидалось %v", got, tt.want)
+			}
+			if rejected != tt.rejected {
+				t.Errorf("отклонено %d, ожидалось %d", rejected, tt.rejected)
+			}
+			if queue.Cap() != 3 {
+				t.Errorf("Cap = %d, ожидалось 3", queue.Cap())
+			}
+
+			// После извлечения в очереди снова есть место.
+			value, err := queue.Dequeue()
+			if err != nil || value != tt.want[0] {
+				t.Fatalf("Dequeue = %d, %v, ожидалось %d", value, err, tt.want[0])
+			}
+			if err := queue.TryEnqueue(100); err != nil {
+				t.Errorf("TryEnqueue после Dequeue: %v", err)
+			}
+		})
+	}
+}
+
+func TestRingQueueJSON(t *testing.T) {
+	queue := NewRingQueue[string]()
+	for _, value := range []string{"a", "b", "c"} {
+		queue.Enqueue(value)
+	}
+	queue.Dequeue()
+	data, err := json.Marshal(queue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bounded := NewBoundedRingQueue[string](1, OverwriteOldest)
+	if err := json.Unmarshal(data, bounded); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := bounded.Values(), []string{"c"}; !slices.Equal(got, want) {
+		t.Errorf("Values = %q, ожидалось %q", got, want)
+	}
+}
+
+func BenchmarkRingQueue(b *testing.B) {
+	b.ReportAllocs()
+	queue := NewRingQueue[int]()
+	for i := 0; i < b.N; i++ {
+		queue.Enqueue(i)
+		if queue.Len() > 64 {
+			queue.Dequeue()
+		}
+	}
+}
+
+func BenchmarkQueue(b *testing.B) {
+	b.ReportAllocs()
+	queue := NewQueue[int]()
+	for i := 0; i < b.N; i++ {
+		queue.Enqueue(i)
+		if queue.Len() > 64 {
+			queue.Dequeue()
+		}
+	}
+}
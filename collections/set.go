@@ -0,0 +1,226 @@
+package collections
+
+import "encoding/json"
+
+// Set представляет множество. Элементы хранятся в хеш-таблице (map), поэтому
+// добавление, проверка и удаление выполняются за O(1). По умолчанию
+// множество помнит порядок добавления элементов, и Values возвращает их в
+// этом порядке.
+type Set[T comparable] struct {
+	items     map[T]int // для упорядоченного множества — позиция в order
+	order     []setItem[T]
+	removed   int // число удалённых элементов, оставшихся в order
+	unordered bool
+}
+
+type setItem[T comparable] struct {
+	value T
+	live  bool
+}
+
+// NewSet создает новое множество, сохраняющее порядок добавления.
+func NewSet[T comparable]() *Set[T] {
+	return &Set[T]{items: make(map[T]int)}
+}
+
+// NewUnorderedSet создает новое множество без учёта порядка добавления.
+// Оно расходует меньше памяти, но Values возвращает элементы в
+// произвольном порядке.
+func NewUnorderedSet[T comparable]() *Set[T] {
+	return &Set[T]{items: make(map[T]int), unordered: true}
+}
+
+// Add добавляет элемент в множество.
+func (set *Set[T]) Add(value T) {
+	// Проверяем, есть ли элемент уже в множестве
+	if set.Contains(value) {
+		return
+	}
+	if set.items == nil {
+		set.items = make(map[T]int)
+	}
+	if set.unordered {
+		set.items[value] = 0
+		return
+	}
+	set.items[value] = len(set.order)
+	set.order = append(set.order, setItem[T]{value: value, live: true})
+}
+
+// Contains проверяет, содержит ли множество указанный элемент.
+func (set *Set[T]) Contains(value T) bool {
+	_, ok := set.items[value]
+	return ok
+}
+
+// Remove удаляет элемент из множества.
+func (set *Set[T]) Remove(value T) {
+	position, ok := set.items[value]
+	if !ok {
+		return
+	}
+	delete(set.items, value)
+	if set.unordered {
+		return
+	}
+
+	// Элемент только помечается удалённым; order уплотняется, когда
+	// удалённых становится больше половины.
+	set.order[position] = setItem[T]{}
+	set.removed++
+	if set.removed > len(set.order)/2 {
+		set.compact()
+	}
+}
+
+// Len возвращает количество элементов множества.
+func (set *Set[T]) Len() int {
+	return len(set.items)
+}
+
+// Values возвращает элементы множества. Для упорядоченного множества
+// элементы идут в порядке добавления.
+func (set *Set[T]) Values() []T {
+	values := make([]T, 0, len(set.items))
+	if set.unordered {
+		for value := range set.items {
+			values = append(values, value)
+		}
+		return values
+	}
+	for _, item := range set.order {
+		if item.live {
+			values = append(values, item.value)
+		}
+	}
+	return values
+}
+
+// compact удаляет из order помеченные элементы и обновляет позиции.
+func (set *Set[T]) compact() {
+	live := set.order[:0]
+	for _, item := range set.order {
+		if item.live {
+			set.items[item.value] = len(live)
+			live = append(live, item)
+		}
+	}
+	clear(set.order[len(live):])
+	set.order = live
+	set.removed = 0
+}
+
+// NewLike создает пустое множество того же вида (упорядоченное или нет),
+// что и set.
+func (set *Set[T]) NewLike() *Set[T] {
+	if set.unordered {
+		return NewUnorderedSet[T]()
+	}
+	return NewSet[T]()
+}
+
+// Union возвращает объединение множеств: сначала элементы set, затем
+// элементы other, которых нет в set.
+func (set *Set[T]) Union(other *Set[T]) *Set[T] {
+	result := set.NewLike()
+	for _, value := range set.Values() {
+		result.Add(value)
+	}
+	for _, value := range other.Values() {
+		result.Add(value)
+	}
+	return result
+}
+
+// Intersect возвращает пересечение множеств в порядке элементов set.
+func (set *Set[T]) Intersect(other *Set[T]) *Set[T] {
+	result := set.NewLike()
+	for _, value := range set.Values() {
+		if other.Contains(value) {
+			result.Add(value)
+		}
+	}
+	return result
+}
+
+// Difference возвращает элементы set, которых нет в other.
+func (set *Set[T]) Difference(other *Set[T]) *Set[T] {
+	result := set.NewLike()
+	for _, value := range set.Values() {
+		if !other.Contains(value) {
+			result.Add(value)
+		}
+	}
+	return result
+}
+
+// SymmetricDifference возвращает элементы, входящие ровно в одно из
+// множеств: сначала из set, затем из other.
+func (set *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	result := set.Difference(other)
+	for _, value := range other.Values() {
+		if !set.Contains(value) {
+			result.Add(value)
+		}
+	}
+	return result
+}
+
+// IsSubset проверяет, что каждый элемент set входит в other.
+func (set *Set[T]) IsSubset(other *Set[T]) bool {
+	if set.Len() > other.Len() {
+		return false
+	}
+	for value := range set.items {
+		if !other.Contains(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset проверяет, что set содержит каждый элемент other.
+func (set *Set[T]) IsSuperset(other *Set[T]) bool {
+	return other.IsSubset(set)
+}
+
+// Equal проверяет, что множества состоят из одних и тех же элементов.
+// Порядок добавления не учитывается.
+func (set *Set[T]) Equal(other *Set[T]) bool {
+	return set.Len() == other.Len() && set.IsSubset(other)
+}
+
+// Disjoint проверяет, что у множеств нет общих элементов.
+func (set *Set[T]) Disjoint(other *Set[T]) bool {
+	small, large := set, other
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	for value := range small.items {
+		if large.Contains(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalJSON представляет множество JSON-массивом в порядке Values.
+func (set *Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.Values())
+}
+
+// UnmarshalJSON заменяет содержимое множества элементами JSON-массива.
+// Повторяющиеся элементы массива добавляются один раз.
+func (set *Set[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	set.items = make(map[T]int, len(values))
+	set.order = nil
+	set.removed = 0
+	for _, value := range values {
+		set.Add(value)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+func setOf(values ...string) *Set[string] {
+	set := NewSet[string]()
+	for _, value := range values {
+		set.Add(value)
+	}
+	return set
+}
+
+func TestSetOperations(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		// Ожидаемые результаты в порядке, который обещают комментарии.
+		union, intersect, difference, symdiff []string
+		subset, superset, equal, disjoint     bool
+	}{
+		{
+			name: "пересекающиеся",
+			a:    []string{"c", "a", "b"}, b: []string{"d", "b", "c"},
+			union: []string{"c", "a", "b", "d"}, intersect: []string{"c", "b"},
+			difference: []string{"a"}, symdiff: []string{"a", "d"},
+		},
+		{
+			name: "подмножество",
+			a:    []string{"b", "a"}, b: []string{"a", "x", "b"},
+			union: []string{"b", "a", "x"}, intersect: []string{"b", "a"},
+			difference: []string{}, symdiff: []string{"x"},
+			subset: true,
+		},
+		{
+			name: "равные в разном порядке",
+			a:    []string{"a", "b"}, b: []string{"b", "a"},
+			union: []string{"a", "b"}, intersect: []string{"a", "b"},
+			difference: []string{}, symdiff: []string{},
+			subset: true, superset: true, equal: true,
+		},
+		{
+			name: "непересекающиеся",
+			a:    []string{"a"}, b: []string{"z", "y"},
+			union: []string{"a", "z", "y"}, intersect: []string{},
+			difference: []string{"a"}, symdiff: []string{"a", "z", "y"},
+			disjoint: true,
+		},
+		{
+			name: "пустое и непустое",
+			a:    nil, b: []string{"a"},
+			union: []string{"a"}, intersect: []string{},
+			difference: []string{}, symdiff: []string{"a"},
+			subset: true, disjoint: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := setOf(tt.a...), setOf(tt.b...)
+			results := []struct {
+				op   string
+				got  *Set[string]
+				want []string
+			}{
+				{"Union", a.Union(b), tt.union},
+				{"Intersect", a.Intersect(b), tt.intersect},
+				{"Difference", a.Difference(b), tt.difference},
+				{"SymmetricDifference", a.SymmetricDifference(b), tt.symdiff},
+			}
+			for _, r := range results {
+				if got := r.got.Values(); !slices.Equal(got, r.want) {
+					t.Errorf("%s = %q, ожидалось %q", r.op, got, r.want)
+				}
+			}
+			checks := []struct {
+				op        string
+				got, want bool
+			}{
+				{"IsSubset", a.IsSubset(b), tt.subset},
+				{"IsSuperset", a.IsSuperset(b), tt.superset},
+				{"Equal", a.Equal(b), tt.equal},
+				{"Disjoint", a.Disjoint(b), tt.disjoint},
+			}
+			for _, c := range checks {
+				if c.got != c.want {
+					t.Errorf("%s = %t, ожидалось %t", c.op, c.got, c.want)
+				}
+			}
+
+			// Операции не меняют исходные множества.
+			if !slices.Equal(a.Values(), setOf(tt.a...).Values()) || !slices.Equal(b.Values(), setOf(tt.b...).Values()) {
+				t.Error("операции изменили исходные множества")
+			}
+		})
+	}
+}
+
+func TestSetOperationsKeepKind(t *testing.T) {
+	a := NewUnorderedSet[int]()
+	a.Add(1)
+	b := NewSet[int]()
+	b.Add(2)
+	union := a.Union(b)
+	if !union.unordered || union.Len() != 2 {
+		t.Errorf("Union неупорядоченного множества: unordered = %t, Len = %d", union.unordered, union.Len())
+	}
+}
+
+func TestSetOrderAfterRemove(t *testing.T) {
+	set := setOf("a", "b", "c", "d", "e")
+	set.Remove("b")
+	set.Remove("d")
+	set.Remove("a") // удалённых больше половины — order уплотняется
+	set.Add("b")
+	if got, want := set.Values(), []string{"c", "e", "b"}; !slices.Equal(got, want) {
+		t.Errorf("Values = %q, ожидалось %q", got, want)
+	}
+	if got, want := set.Intersect(setOf("b", "c")).Values(), []string{"c", "b"}; !slices.Equal(got, want) {
+		t.Errorf("Intersect = %q, ожидалось %q", got, want)
+	}
+}
@@ -0,0 +1,93 @@
+// Package collections содержит структуры данных, используемые программой
+// laba1: стек, очередь, очередь с приоритетом, двустороннюю очередь,
+// множество и хеш-таблицу. Сами структуры не синхронизированы; для работы
+// из нескольких горутин есть обёртки SyncStack, SyncQueue, SyncSet и
+// SyncHashTable.
+package collections
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+)
+
+// Node представляет узел для стека и очереди.
+type Node[T any] struct {
+	data T
+	next *Node[T]
+}
+
+// Stack представляет стек.
+type Stack[T any] struct {
+	head *Node[T]
+	size int
+}
+
+// NewStack создает новый пустой стек.
+func NewStack[T any]() *Stack[T] {
+	return &Stack[T]{}
+}
+
+// Push добавляет элемент на вершину стека.
+func (stack *Stack[T]) Push(value T) {
+	node := &Node[T]{data: value}
+	if stack.head == nil {
+		stack.head = node
+	} else {
+		node.next = stack.head
+		stack.head = node
+	}
+	stack.size++
+}
+
+// Pop удаляет и возвращает элемент с вершины стека.
+func (stack *Stack[T]) Pop() (T, error) {
+	if stack.head == nil {
+		var zero T
+		return zero, errors.New("стек пуст")
+	}
+	value := stack.head.data
+	stack.head = stack.head.next
+	stack.size--
+	return value, nil
+}
+
+// Len возвращает количество элементов стека.
+func (stack *Stack[T]) Len() int {
+	return stack.size
+}
+
+// Values возвращает элементы стека, начиная с вершины.
+func (stack *Stack[T]) Values() []T {
+	var values []T
+	for current := stack.head; current != nil; current = current.next {
+		values = append(values, current.data)
+	}
+	return values
+}
+
+// MarshalJSON представляет стек JSON-массивом от дна к вершине, то есть в
+// порядке, в котором элементы добавлялись через Push.
+func (stack *Stack[T]) MarshalJSON() ([]byte, error) {
+	values := stack.Values()
+	slices.Reverse(values)
+	if values == nil {
+		values = []T{}
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON заменяет содержимое стека элементами JSON-массива; последний
+// элемент массива становится вершиной.
+func (stack *Stack[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	stack.head = nil
+	stack.size = 0
+	for _, value := range values {
+		stack.Push(value)
+	}
+	return nil
+}
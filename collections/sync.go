@@ -0,0 +1,349 @@
+package collections
+
+import "sync"
+
+// Потокобезопасные варианты структур. Каждая обёртка защищает структуру
+// своим sync.RWMutex: изменяющие методы берут блокировку на запись, а
+// читающие (Contains, Get, Len, Values) — на чтение и поэтому могут
+// выполняться параллельно. Для составных операций, которые должны быть
+// атомарными целиком, предназначены методы Do (запись) и View (чтение):
+// внутри переданной функции нельзя вызывать методы той же обёртки. Нулевое
+// значение любой обёртки — пустая структура, готовая к работе.
+
+// SyncStack — стек, безопасный для одновременного использования из
+// нескольких горутин.
+type SyncStack[T any] struct {
+	mu    sync.RWMutex
+	stack Stack[T]
+}
+
+// NewSyncStack создает новый пустой потокобезопасный стек.
+func NewSyncStack[T any]() *SyncStack[T] {
+	return &SyncStack[T]{}
+}
+
+// Push добавляет элемент на вершину стека.
+func (s *SyncStack[T]) Push(value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stack.Push(value)
+}
+
+// Pop извлекает элемент с вершины стека.
+func (s *SyncStack[T]) Pop() (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.Pop()
+}
+
+// Len возвращает количество элементов стека.
+func (s *SyncStack[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.stack.Len()
+}
+
+// Values возвращает копию элементов стека, начиная с вершины.
+func (s *SyncStack[T]) Values() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.stack.Values()
+}
+
+// Do вызывает fn со стеком под блокировкой на запись.
+func (s *SyncStack[T]) Do(fn func(stack *Stack[T])) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.stack)
+}
+
+// View вызывает fn со стеком под блокировкой на чтение. fn не должна
+// изменять стек.
+func (s *SyncStack[T]) View(fn func(stack *Stack[T])) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fn(&s.stack)
+}
+
+// MarshalJSON представляет стек JSON-массивом так же, как Stack.
+func (s *SyncStack[T]) MarshalJSON() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.stack.MarshalJSON()
+}
+
+// UnmarshalJSON заменяет содержимое стека элементами JSON-массива.
+func (s *SyncStack[T]) UnmarshalJSON(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.UnmarshalJSON(data)
+}
+
+// SyncQueue — очередь, безопасная для одновременного использования из
+// нескольких горутин.
+type SyncQueue[T any] struct {
+	mu    sync.RWMutex
+	queue Queue[T]
+}
+
+// NewSyncQueue создает новую пустую потокобезопасную очередь.
+func NewSyncQueue[T any]() *SyncQueue[T] {
+	return &SyncQueue[T]{}
+}
+
+// Enqueue добавляет элемент в конец очереди.
+func (q *SyncQueue[T]) Enqueue(value T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.queue.Enqueue(value)
+}
+
+// Dequeue извлекает элемент из начала очереди.
+func (q *SyncQueue[T]) Dequeue() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Dequeue()
+}
+
+// Len возвращает количество элементов очереди.
+func (q *SyncQueue[T]) Len() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.queue.Len()
+}
+
+// Values возвращает копию элементов очереди от начала к концу.
+func (q *SyncQueue[T]) Values() []T {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.queue.Values()
+}
+
+// Do вызывает fn с очередью под блокировкой на запись.
+func (q *SyncQueue[T]) Do(fn func(queue *Queue[T])) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	fn(&q.queue)
+}
+
+// View вызывает fn с очередью под блокировкой на чтение. fn не должна
+// изменять очередь.
+func (q *SyncQueue[T]) View(fn func(queue *Queue[T])) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	fn(&q.queue)
+}
+
+// MarshalJSON представляет очередь JSON-массивом так же, как Queue.
+func (q *SyncQueue[T]) MarshalJSON() ([]byte, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.queue.MarshalJSON()
+}
+
+// UnmarshalJSON заменяет содержимое очереди элементами JSON-массива.
+func (q *SyncQueue[T]) UnmarshalJSON(data []byte) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.UnmarshalJSON(data)
+}
+
+// SyncSet — множество, безопасное для одновременного использования из
+// нескольких горутин.
+type SyncSet[T comparable] struct {
+	mu  sync.RWMutex
+	set Set[T]
+}
+
+// NewSyncSet создает новое потокобезопасное множество, сохраняющее порядок
+// добавления.
+func NewSyncSet[T comparable]() *SyncSet[T] {
+	return &SyncSet[T]{}
+}
+
+// Add добавляет элемент в множество.
+func (s *SyncSet[T]) Add(value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Add(value)
+}
+
+// Contains проверяет, содержит ли множество элемент.
+func (s *SyncSet[T]) Contains(value T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Contains(value)
+}
+
+// Remove удаляет элемент из множества.
+func (s *SyncSet[T]) Remove(value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Remove(value)
+}
+
+// Len возвращает количество элементов множества.
+func (s *SyncSet[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Len()
+}
+
+// Values возвращает копию элементов множества в порядке добавления.
+func (s *SyncSet[T]) Values() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Values()
+}
+
+// Snapshot возвращает независимую копию множества. Её удобно использовать
+// для операций над несколькими множествами без одновременной блокировки
+// их всех.
+func (s *SyncSet[T]) Snapshot() *Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Union(s.set.NewLike())
+}
+
+// Do вызывает fn с множеством под блокировкой на запись.
+func (s *SyncSet[T]) Do(fn func(set *Set[T])) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.set)
+}
+
+// View вызывает fn с множеством под блокировкой на чтение. fn не должна
+// изменять множество.
+func (s *SyncSet[T]) View(fn func(set *Set[T])) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fn(&s.set)
+}
+
+// MarshalJSON представляет множество JSON-массивом так же, как Set.
+func (s *SyncSet[T]) MarshalJSON() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.MarshalJSON()
+}
+
+// UnmarshalJSON заменяет содержимое множества элементами JSON-массива.
+func (s *SyncSet[T]) UnmarshalJSON(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.UnmarshalJSON(data)
+}
+
+// SyncHashTable — хеш-таблица, безопасная для одновременного использования
+// из нескольких горутин. Поиск (Get) выполняется под блокировкой на чтение
+// и не мешает другим читателям.
+type SyncHashTable[K comparable, V any] struct {
+	mu    sync.RWMutex
+	table HashTable[K, V]
+}
+
+// NewSyncHashTable создает новую потокобезопасную хеш-таблицу с теми же
+// параметрами, что и NewHashTable.
+func NewSyncHashTable[K comparable, V any](size int, hasher Hasher[K]) *SyncHashTable[K, V] {
+	return &SyncHashTable[K, V]{table: *NewHashTable[K, V](size, hasher)}
+}
+
+// SetMaxSize ограничивает рост таблицы size ячейками.
+func (t *SyncHashTable[K, V]) SetMaxSize(size int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.table.SetMaxSize(size)
+}
+
+// SetValueEqual задаёт функцию сравнения значений для CompareAndSwap.
+func (t *SyncHashTable[K, V]) SetValueEqual(equal func(a, b V) bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.table.SetValueEqual(equal)
+}
+
+// Put добавляет или обновляет пару ключ:значение.
+func (t *SyncHashTable[K, V]) Put(key K, value V) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.table.Put(key, value)
+}
+
+// PutIfAbsent добавляет пару, только если ключа ещё нет в таблице.
+func (t *SyncHashTable[K, V]) PutIfAbsent(key K, value V) (V, bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.table.PutIfAbsent(key, value)
+}
+
+// Replace заменяет значение существующего ключа.
+func (t *SyncHashTable[K, V]) Replace(key K, value V) (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.table.Replace(key, value)
+}
+
+// CompareAndSwap заменяет значение ключа, только если оно равно old.
+func (t *SyncHashTable[K, V]) CompareAndSwap(key K, old, newValue V) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.table.CompareAndSwap(key, old, newValue)
+}
+
+// Get возвращает значение по ключу.
+func (t *SyncHashTable[K, V]) Get(key K) (V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.table.Get(key)
+}
+
+// Delete удаляет запись по ключу.
+func (t *SyncHashTable[K, V]) Delete(key K) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.table.Delete(key)
+}
+
+// Len возвращает количество записей в таблице.
+func (t *SyncHashTable[K, V]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.table.Len()
+}
+
+// Range вызывает fn для каждой записи под блокировкой на чтение. fn не
+// должна вызывать методы таблицы.
+func (t *SyncHashTable[K, V]) Range(fn func(key K, value V) bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	t.table.Range(fn)
+}
+
+// Do вызывает fn с таблицей под блокировкой на запись.
+func (t *SyncHashTable[K, V]) Do(fn func(table *HashTable[K, V])) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fn(&t.table)
+}
+
+// View вызывает fn с таблицей под блокировкой на чтение. fn не должна
+// изменять таблицу.
+func (t *SyncHashTable[K, V]) View(fn func(table *HashTable[K, V])) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	fn(&t.table)
+}
+
+// MarshalJSON представляет таблицу JSON-объектом так же, как HashTable.
+func (t *SyncHashTable[K, V]) MarshalJSON() ([]byte, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.table.MarshalJSON()
+}
+
+// UnmarshalJSON заменяет содержимое таблицы записями JSON-объекта.
+func (t *SyncHashTable[K, V]) UnmarshalJSON(data []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.table.UnmarshalJSON(data)
+}
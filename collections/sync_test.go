@@ -0,0 +1,139 @@
+package collections
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+const (
+	stressGoroutines = 8
+	stressIterations = 500
+)
+
+// stress запускает writer и reader в нескольких горутинах одновременно.
+func stress(writer func(g, i int), reader func()) {
+	var wg sync.WaitGroup
+	for g := 0; g < stressGoroutines; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < stressIterations; i++ {
+				writer(g, i)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < stressIterations; i++ {
+				reader()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSyncStackConcurrent(t *testing.T) {
+	stack := NewSyncStack[int]()
+	stress(func(g, i int) {
+		stack.Push(i)
+		if i%2 == 1 {
+			if _, err := stack.Pop(); err != nil {
+				t.Errorf("Pop: %v", err)
+			}
+		}
+	}, func() {
+		if n := stack.Len(); n < 0 {
+			t.Errorf("Len = %d", n)
+		}
+		stack.Values()
+		stack.MarshalJSON()
+	})
+	if got, want := stack.Len(), stressGoroutines*stressIterations/2; got != want {
+		t.Errorf("Len = %d, ожидалось %d", got, want)
+	}
+}
+
+func TestSyncQueueConcurrent(t *testing.T) {
+	queue := NewSyncQueue[int]()
+	stress(func(g, i int) {
+		queue.Enqueue(i)
+		if i%2 == 1 {
+			if _, err := queue.Dequeue(); err != nil {
+				t.Errorf("Dequeue: %v", err)
+			}
+		}
+	}, func() {
+		queue.Len()
+		queue.Values()
+		queue.View(func(queue *Queue[int]) { queue.Len() })
+	})
+	if got, want := queue.Len(), stressGoroutines*stressIterations/2; got != want {
+		t.Errorf("Len = %d, ожидалось %d", got, want)
+	}
+}
+
+func TestSyncSetConcurrent(t *testing.T) {
+	set := NewSyncSet[string]()
+	stress(func(g, i int) {
+		value := strconv.Itoa(g) + ":" + strconv.Itoa(i)
+		set.Add(value)
+		if i%2 == 1 {
+			set.Remove(value)
+		}
+	}, func() {
+		set.Contains("0:0")
+		set.Len()
+		set.Snapshot()
+	})
+	if got, want := set.Len(), stressGoroutines*stressIterations/2; got != want {
+		t.Errorf("Len = %d, ожидалось %d", got, want)
+	}
+	for g := 0; g < stressGoroutines; g++ {
+		if !set.Contains(strconv.Itoa(g) + ":0") {
+			t.Errorf("нет элемента %d:0", g)
+		}
+	}
+}
+
+func TestSyncHashTableConcurrent(t *testing.T) {
+	table := NewSyncHashTable[string, int](4, nil)
+	stress(func(g, i int) {
+		key := strconv.Itoa(g) + ":" + strconv.Itoa(i)
+		if _, err := table.Put(key, i); err != nil {
+			t.Errorf("Put: %v", err)
+		}
+		if i%2 == 1 {
+			table.Delete(key)
+		}
+		table.CompareAndSwap(key, i, i+1)
+	}, func() {
+		table.Get("0:0")
+		table.Len()
+		table.Range(func(key string, value int) bool { return true })
+	})
+	if got, want := table.Len(), stressGoroutines*stressIterations/2; got != want {
+		t.Errorf("Len = %d, ожидалось %d", got, want)
+	}
+	if value, ok := table.Get("0:0"); !ok || value != 1 {
+		t.Errorf("Get(0:0) = %d, %t, ожидалось 1, true", value, ok)
+	}
+}
+
+func TestSyncZeroValues(t *testing.T) {
+	var stack SyncStack[int]
+	stack.Push(1)
+	var queue SyncQueue[int]
+	queue.Enqueue(1)
+	var set SyncSet[string]
+	set.Add("a")
+	var table SyncHashTable[string, int]
+	if _, err := table.Put("a", 1); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if stack.Len() != 1 || queue.Len() != 1 || !set.Contains("a") || table.Len() != 1 {
+		t.Errorf("Len: стек %d, очередь %d, множество %d, таблица %d", stack.Len(), queue.Len(), set.Len(), table.Len())
+	}
+	if value, ok := table.Get("a"); !ok || value != 1 {
+		t.Errorf("Get = %d, %t", value, ok)
+	}
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/semishida/Laba1/storage"
+)
+
+// Коды завершения неинтерактивных команд.
+const (
+	exitOK       = 0
+	exitNotFound = 1 // элемент не найден или структура пуста
+	exitUsage    = 2 // неверная команда или аргументы
+	exitFailure  = 3 // ошибка загрузки или сохранения
+)
+
+const commandUsage = `Использование: laba1 [флаги] <структура> <операция> [аргументы]
+  stack push <значение> | pop | list
+  queue enqueue <значение> | dequeue | list
+  set add <значение> | contains <значение> | remove <значение> | list
+  table put <ключ> <значение> | get <ключ> | delete <ключ> | list
+Коды завершения: 0 — успех, 1 — элемент не найден или структура пуста,
+2 — неверная команда, 3 — ошибка загрузки или сохранения.
+set add выводит 1, если элемент добавлен, и 0, если он уже был в множестве.`
+
+// errUsage сообщает о неверной команде; текст ошибки выводится вместе со
+// справкой commandUsage.
+var errUsage = errors.New("неверная команда")
+
+// commandResult — результат одной команды: строки для stdout, сообщение
+// для stderr и код завершения.
+type commandResult struct {
+	lines   []string
+	message string
+	code    int
+}
+
+// Функция для выполнения одной неинтерактивной команды. Команда загружает
+// файл нужной структуры, выполняет операцию, сохраняет изменения и выводит
+// результат в stdout. Возвращает код завершения процесса.
+func runCommand(args []string, cfg *storageConfig, data *dataSet, stdout, stderr io.Writer) int {
+	if len(args) < 2 {
+		fmt.Fprintln(stderr, commandUsage)
+		return exitUsage
+	}
+
+	var result commandResult
+	var err error
+	switch args[0] {
+	case "stack":
+		result, err = runStackCommand(args[1], args[2:], cfg, data)
+	case "queue":
+		result, err = runQueueCommand(args[1], args[2:], cfg, data)
+	case "set":
+		result, err = runSetCommand(args[1], args[2:], cfg, data)
+	case "table":
+		result, err = runTableCommand(args[1], args[2:], cfg, data)
+	default:
+		err = fmt.Errorf("%w: неизвестная структура %q", errUsage, args[0])
+	}
+
+	if errors.Is(err, errUsage) {
+		fmt.Fprintln(stderr, "Ошибка:", err)
+		fmt.Fprintln(stderr, commandUsage)
+		return exitUsage
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "Ошибка:", err)
+		return exitFailure
+	}
+	for _, line := range result.lines {
+		fmt.Fprintln(stdout, line)
+	}
+	if result.message != "" {
+		fmt.Fprintln(stderr, result.message)
+	}
+	return result.code
+}
+
+func runStackCommand(op string, args []string, cfg *storageConfig, data *dataSet) (commandResult, error) {
+	if err := prepareCommand(storage.LoadStack(data.stack, cfg.stackFile, cfg.options), cfg.stackFile, &cfg.journals.stack, cfg.wal); err != nil {
+		return commandResult{}, err
+	}
+	defer closeJournals(cfg)
+
+	switch op {
+	case "push":
+		if err := checkArgs(op, args, 1); err != nil {
+			return commandResult{}, err
+		}
+		data.stack.Push(args[0])
+		return commandResult{}, cfg.recordStack(data.stack, storage.OpPush, args[0])
+	case "pop":
+		if err := checkArgs(op, args, 0); err != nil {
+			return commandResult{}, err
+		}
+		value, err := data.stack.Pop()
+		if err != nil {
+			return commandResult{message: err.Error(), code: exitNotFound}, nil
+		}
+		return commandResult{lines: []string{value}}, cfg.recordStack(data.stack, storage.OpPop)
+	case "list":
+		return commandResult{lines: data.stack.Values()}, checkArgs(op, args, 0)
+	default:
+		return commandResult{}, fmt.Errorf("%w: неизвестная операция стека %q", errUsage, op)
+	}
+}
+
+func runQueueCommand(op string, args []string, cfg *storageConfig, data *dataSet) (commandResult, error) {
+	if err := prepareCommand(storage.LoadQueue(data.queue, cfg.queueFile, cfg.options), cfg.queueFile, &cfg.journals.queue, cfg.wal); err != nil {
+		return commandResult{}, err
+	}
+	defer closeJournals(cfg)
+
+	switch op {
+	case "enqueue":
+		if err := checkArgs(op, args, 1); err != nil {
+			return commandResult{}, err
+		}
+		data.queue.Enqueue(args[0])
+		return commandResult{}, cfg.recordQueue(data.queue, storage.OpEnqueue, args[0])
+	case "dequeue":
+		if err := checkArgs(op, args, 0); err != nil {
+			return commandResult{}, err
+		}
+		value, err := data.queue.Dequeue()
+		if err != nil {
+			return commandResult{message: err.Error(), code: exitNotFound}, nil
+		}
+		return commandResult{lines: []string{value}}, cfg.recordQueue(data.queue, storage.OpDequeue)
+	case "list":
+		return commandResult{lines: data.queue.Values()}, checkArgs(op, args, 0)
+	default:
+		return commandResult{}, fmt.Errorf("%w: неизвестная операция очереди %q", errUsage, op)
+	}
+}
+
+func runSetCommand(op string, args []string, cfg *storageConfig, data *dataSet) (commandResult, error) {
+	if err := prepareCommand(storage.LoadSet(data.set, cfg.setFile, cfg.options), cfg.setFile, &cfg.journals.set, cfg.wal); err != nil {
+		return commandResult{}, err
+	}
+	defer closeJournals(cfg)
+
+	switch op {
+	case "add":
+		if err := checkArgs(op, args, 1); err != nil {
+			return commandResult{}, err
+		}
+		// Как SADD в сценариях: повторное добавление не ошибка, а результат
+		// "0".
+		if data.set.Contains(args[0]) {
+			return commandResult{lines: []string{"0"}}, nil
+		}
+		data.set.Add(args[0])
+		return commandResult{lines: []string{"1"}}, cfg.recordSet(data.set, storage.OpAdd, args[0])
+	case "contains":
+		if err := checkArgs(op, args, 1); err != nil {
+			return commandResult{}, err
+		}
+		if !data.set.Contains(args[0]) {
+			return commandResult{lines: []string{"нет"}, code: exitNotFound}, nil
+		}
+		return commandResult{lines: []string{"да"}}, nil
+	case "remove":
+		if err := checkArgs(op, args, 1); err != nil {
+			return commandResult{}, err
+		}
+		if !data.set.Contains(args[0]) {
+			return commandResult{message: "элемент не найден в множестве", code: exitNotFound}, nil
+		}
+		data.set.Remove(args[0])
+		return commandResult{}, cfg.recordSet(data.set, storage.OpRemove, args[0])
+	case "list":
+		return commandResult{lines: data.set.Values()}, checkArgs(op, args, 0)
+	default:
+		return commandResult{}, fmt.Errorf("%w: неизвестная операция множества %q", errUsage, op)
+	}
+}
+
+func runTableCommand(op string, args []string, cfg *storageConfig, data *dataSet) (commandResult, error) {
+	if err := prepareCommand(storage.LoadHashTable(data.hashTable, cfg.tableFile, cfg.options), cfg.tableFile, &cfg.journals.table, cfg.wal); err != nil {
+		return commandResult{}, err
+	}
+	defer closeJournals(cfg)
+
+	switch op {
+	case "put":
+		if err := checkArgs(op, args, 2); err != nil {
+			return commandResult{}, err
+		}
+		if _, err := data.hashTable.Put(args[0], args[1]); err != nil {
+			return commandResult{}, err
+		}
+		return commandResult{}, cfg.recordHashTable(data.hashTable, storage.OpPut, args[0], args[1])
+	case "get":
+		if err := checkArgs(op, args, 1); err != nil {
+			return commandResult{}, err
+		}
+		value, found := data.hashTable.Get(args[0])
+		if !found {
+			return commandResult{message: "ключ не найден", code: exitNotFound}, nil
+		}
+		return commandResult{lines: []string{value}}, nil
+	case "delete":
+		if err := checkArgs(op, args, 1); err != nil {
+			return commandResult{}, err
+		}
+		if _, found := data.hashTable.Get(args[0]); !found {
+			return commandResult{message: "ключ не найден", code: exitNotFound}, nil
+		}
+		data.hashTable.Delete(args[0])
+		return commandResult{}, cfg.recordHashTable(data.hashTable, storage.OpDelete, args[0])
+	case "list":
+		var lines []string
+		data.hashTable.Range(func(key, value string) bool {
+			lines = append(lines, key+"\t"+value)
+			return true
+		})
+		return commandResult{lines: lines}, checkArgs(op, args, 0)
+	default:
+		return commandResult{}, fmt.Errorf("%w: неизвестная операция хеш-таблицы %q", errUsage, op)
+	}
+}
+
+// prepareCommand проверяет результат загрузки структуры (отсутствие файла
+// не считается ошибкой) и при включённом журнале открывает его.
+func prepareCommand(loadErr error, filename string, journal **storage.Journal, wal bool) error {
+	if loadErr != nil && !errors.Is(loadErr, fs.ErrNotExist) {
+		return loadErr
+	}
+	if !wal {
+		return nil
+	}
+	var err error
+	*journal, err = storage.OpenJournal(filename)
+	return err
+}
+
+// checkArgs проверяет количество аргументов операции.
+func checkArgs(op string, args []string, want int) error {
+	if len(args) != want {
+		return fmt.Errorf("%w: %s ожидает аргументов: %d, получено: %d", errUsage, op, want, len(args))
+	}
+	return nil
+}
@@ -0,0 +1,3 @@
+module github.com/semishida/Laba1
+
+go 1.24
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/semishida/Laba1/storage"
+)
+
+// httpServer предоставляет структуры через REST API с JSON-ответами:
+//
+//	GET /stack, POST /stack {"value": v}, DELETE /stack — стек;
+//	GET /queue, POST /queue {"value": v}, DELETE /queue — очередь;
+//	GET /set, GET|PUT|DELETE /set/{v} — множество;
+//	GET /table, GET|PUT|DELETE /table/{key} (PUT с {"value": v}) — хеш-таблица.
+//
+// Обработчики работают под блокировкой data.mu, а каждое изменение
+// сохраняется так же, как в интерактивном меню.
+type httpServer struct {
+	cfg  *storageConfig
+	data *dataSet
+}
+
+// maxBodySize ограничивает размер тела запроса.
+const maxBodySize = 1 << 20
+
+// valueRequest — тело запросов POST /stack, POST /queue и PUT /table/{key}.
+type valueRequest struct {
+	Value *string `json:"value"`
+}
+
+// Функция для запуска HTTP-сервера на адресе addr. Сервер работает до
+// отмены ctx.
+func runHTTPServer(ctx context.Context, addr string, cfg *storageConfig, data *dataSet, log io.Writer) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(log, "HTTP-сервер слушает", listener.Addr())
+
+	server := &http.Server{
+		Handler:           newHTTPHandler(cfg, data),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       2 * time.Minute,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// newHTTPHandler возвращает обработчик REST API.
+func newHTTPHandler(cfg *storageConfig, data *dataSet) http.Handler {
+	s := &httpServer{cfg: cfg, data: data}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /stack", s.locked(s.listStack))
+	mux.HandleFunc("POST /stack", s.lockedWithValue(s.push))
+	mux.HandleFunc("DELETE /stack", s.locked(s.pop))
+	mux.HandleFunc("GET /queue", s.locked(s.listQueue))
+	mux.HandleFunc("POST /queue", s.lockedWithValue(s.enqueue))
+	mux.HandleFunc("DELETE /queue", s.locked(s.dequeue))
+	mux.HandleFunc("GET /set", s.locked(s.listSet))
+	mux.HandleFunc("GET /set/{value}", s.locked(s.setContains))
+	mux.HandleFunc("PUT /set/{value}", s.locked(s.setAdd))
+	mux.HandleFunc("DELETE /set/{value}", s.locked(s.setRemove))
+	mux.HandleFunc("GET /table", s.locked(s.listTable))
+	mux.HandleFunc("GET /table/{key}", s.locked(s.tableGet))
+	mux.HandleFunc("PUT /table/{key}", s.lockedWithValue(s.tablePut))
+	mux.HandleFunc("DELETE /table/{key}", s.locked(s.tableDelete))
+	return mux
+}
+
+// locked выполняет обработчик под блокировкой структур.
+func (s *httpServer) locked(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.data.mu.Lock()
+		defer s.data.mu.Unlock()
+		handler(w, r)
+	}
+}
+
+// lockedWithValue читает значение из тела запроса и выполняет обработчик
+// под блокировкой структур. Тело читается до взятия блокировки, чтобы
+// медленный клиент не задерживал остальные запросы.
+func (s *httpServer) lockedWithValue(handler func(w http.ResponseWriter, r *http.Request, value string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		value, ok := readValue(w, r)
+		if !ok {
+			return
+		}
+		s.data.mu.Lock()
+		defer s.data.mu.Unlock()
+		handler(w, r, value)
+	}
+}
+
+func (s *httpServer) listStack(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.data.stack)
+}
+
+func (s *httpServer) push(w http.ResponseWriter, r *http.Request, value string) {
+	s.data.stack.Push(value)
+	if !writeStorageError(w, s.cfg.recordStack(s.data.stack, storage.OpPush, value)) {
+		writeJSON(w, http.StatusCreated, map[string]any{"value": value, "size": s.data.stack.Len()})
+	}
+}
+
+func (s *httpServer) pop(w http.ResponseWriter, r *http.Request) {
+	value, err := s.data.stack.Pop()
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if !writeStorageError(w, s.cfg.recordStack(s.data.stack, storage.OpPop)) {
+		writeJSON(w, http.StatusOK, map[string]any{"value": value, "size": s.data.stack.Len()})
+	}
+}
+
+func (s *httpServer) listQueue(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.data.queue)
+}
+
+func (s *httpServer) enqueue(w http.ResponseWriter, r *http.Request, value string) {
+	s.data.queue.Enqueue(value)
+	if !writeStorageError(w, s.cfg.recordQueue(s.data.queue, storage.OpEnqueue, value)) {
+		writeJSON(w, http.StatusCreated, map[string]any{"value": value, "size": s.data.queue.Len()})
+	}
+}
+
+func (s *httpServer) dequeue(w http.ResponseWriter, r *http.Request) {
+	value, err := s.data.queue.Dequeue()
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if !writeStorageError(w, s.cfg.recordQueue(s.data.queue, storage.OpDequeue)) {
+		writeJSON(w, http.StatusOK, map[string]any{"value": value, "size": s.data.queue.Len()})
+	}
+}
+
+func (s *httpServer) listSet(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.data.set)
+}
+
+func (s *httpServer) setContains(w http.ResponseWriter, r *http.Request) {
+	value := r.PathValue("value")
+	status := http.StatusOK
+	member := s.data.set.Contains(value)
+	if !member {
+		status = http.StatusNotFound
+	}
+	writeJSON(w, status, map[string]any{"value": value, "member": member})
+}
+
+func (s *httpServer) setAdd(w http.ResponseWriter, r *http.Request) {
+	value := r.PathValue("value")
+	if s.data.set.Contains(value) {
+		writeJSON(w, http.StatusOK, map[string]any{"value": value, "added": false})
+		return
+	}
+	s.data.set.Add(value)
+	if !writeStorageError(w, s.cfg.recordSet(s.data.set, storage.OpAdd, value)) {
+		writeJSON(w, http.StatusCreated, map[string]any{"value": value, "added": true})
+	}
+}
+
+func (s *httpServer) setRemove(w http.ResponseWriter, r *http.Request) {
+	value := r.PathValue("value")
+	if !s.data.set.Contains(value) {
+		writeError(w, http.StatusNotFound, errors.New("элемент не найден в множестве"))
+		return
+	}
+	s.data.set.Remove(value)
+	if !writeStorageError(w, s.cfg.recordSet(s.data.set, storage.OpRemove, value)) {
+		writeJSON(w, http.StatusOK, map[string]any{"value": value, "removed": true})
+	}
+}
+
+func (s *httpServer) listTable(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.data.hashTable)
+}
+
+func (s *httpServer) tableGet(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	value, found := s.data.hashTable.Get(key)
+	if !found {
+		writeError(w, http.StatusNotFound, errors.New("ключ не найден"))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"key": key, "value": value})
+}
+
+func (s *httpServer) tablePut(w http.ResponseWriter, r *http.Request, value string) {
+	key := r.PathValue("key")
+	updated, err := s.data.hashTable.Put(key, value)
+	if err != nil {
+		writeError(w, http.StatusInsufficientStorage, err)
+		return
+	}
+	if writeStorageError(w, s.cfg.recordHashTable(s.data.hashTable, storage.OpPut, key, value)) {
+		return
+	}
+	status := http.StatusCreated
+	if updated {
+		status = http.StatusOK
+	}
+	writeJSON(w, status, map[string]any{"key": key, "value": value, "updated": updated})
+}
+
+func (s *httpServer) tableDelete(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	if _, found := s.data.hashTable.Get(key); !found {
+		writeError(w, http.StatusNotFound, errors.New("ключ не найден"))
+		return
+	}
+	s.data.hashTable.Delete(key)
+	if !writeStorageError(w, s.cfg.recordHashTable(s.data.hashTable, storage.OpDelete, key)) {
+		writeJSON(w, http.StatusOK, map[string]any{"key": key, "deleted": true})
+	}
+}
+
+// readValue разбирает тело запроса {"value": "..."} размером не больше
+// maxBodySize. При ошибке отвечает клиенту 400 (или 413 для слишком
+// большого тела) и возвращает false.
+func readValue(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var request valueRequest
+	body := http.MaxBytesReader(w, r.Body, maxBodySize)
+	if err := json.NewDecoder(body).Decode(&request); err != nil {
+		status := http.StatusBadRequest
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		writeError(w, status, fmt.Errorf("неверное тело запроса: %w", err))
+		return "", false
+	}
+	if request.Value == nil {
+		writeError(w, http.StatusBadRequest, errors.New(`в теле запроса нет поля "value"`))
+		return "", false
+	}
+	return *request.Value, true
+}
+
+// writeStorageError отвечает клиенту 500, если err не nil, и сообщает, был
+// ли отправлен ответ.
+func writeStorageError(w http.ResponseWriter, err error) bool {
+	if err == nil {
+		return false
+	}
+	writeError(w, http.StatusInternalServerError, fmt.Errorf("ошибка сохранения: %w", err))
+	return true
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -2,725 +2,617 @@ package main
 
 import (
 	"bufio"
-	"errors"
+	"context"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
-)
-
-// Node представляет узел для стека и очереди.
-type Node struct {
-	data string
-	next *Node
-}
-
-// Stack представляет стек.
-type Stack struct {
-	head *Node
-}
+	"syscall"
 
-// Queue представляет очередь.
-type Queue struct {
-	head *Node
-	tail *Node
-}
+	"github.com/semishida/Laba1/collections"
+	"github.com/semishida/Laba1/storage"
+)
 
-// Set представляет множество.
-type Set struct {
-	data []string
-}
+func main() {
+	stackFile := flag.String("stack", "stack.txt", "Файл для стека")
+	queueFile := flag.String("queue", "queue.txt", "Файл для очереди")
+	dequeFile := flag.String("deque", "deque.txt", "Файл для двусторонней очереди")
+	priorityQueueFile := flag.String("pqueue", "priority_queue.txt", "Файл для очереди с приоритетом")
+	setFile := flag.String("set", "set.txt", "Файл для множества")
+	tableFile := flag.String("table", "hash_table.txt", "Файл для хеш-таблицы")
+	format := flag.String("format", "text", "Формат файлов: text, json или binary")
+	wal := flag.Bool("wal", false, "Дописывать изменения в журнал операций вместо перезаписи файлов")
+	compactEvery := flag.Int("compact-every", 100, "Число операций в журнале, после которого файл перезаписывается целиком")
+	backup := flag.Bool("backup", false, "Сохранять предыдущую версию каждого файла с суффиксом .bak")
+	rollback := flag.Bool("rollback", false, "Восстановить файлы из резервных копий .bak и выйти")
+	hashTableSize := flag.Int("table-size", 100, "Начальный размер хеш-таблицы")
+	hashTableMaxSize := flag.Int("table-max-size", 0, "Максимальный размер хеш-таблицы (0 — без ограничения)")
+	setOp := flag.String("set-op", "", "Операция над множествами: union, intersect, difference, symdiff, subset, superset, equal, disjoint")
+	setA := flag.String("set-a", "set.txt", "Файл первого множества для -set-op")
+	setB := flag.String("set-b", "", "Файл второго множества для -set-op")
+	setOut := flag.String("set-out", "", "Файл для результата -set-op")
+	respAddr := flag.String("resp", "", "Запустить RESP-сервер (протокол Redis) на указанном адресе, например 127.0.0.1:6379")
+	httpAddr := flag.String("http", "", "Запустить HTTP/JSON API на указанном адресе, например :8080")
+	script := flag.String("script", "", "Выполнить операции из файла сценария и выйти")
 
-// HashTable представляет хеш-таблицу.
-type HashTable struct {
-	data []hashTableEntry
-}
+	flag.Parse()
 
-type hashTableEntry struct {
-	key   string
-	value string
-}
+	fileFormat, err := storage.ParseFormat(*format)
+	if err != nil {
+		fmt.Println("Ошибка:", err)
+		os.Exit(2)
+	}
 
-// NewSet создает новое множество.
-func NewSet() *Set {
-	return &Set{}
-}
+	cfg := &storageConfig{
+		stackFile:         *stackFile,
+		queueFile:         *queueFile,
+		priorityQueueFile: *priorityQueueFile,
+		dequeFile:         *dequeFile,
+		setFile:           *setFile,
+		tableFile:         *tableFile,
+		options:           storage.Options{Format: fileFormat, Backup: *backup},
 
-// Add добавляет элемент в множество.
-func (set *Set) Add(value string) {
-	// Проверяем, есть ли элемент уже в множестве
-	if !set.Contains(value) {
-		set.data = append(set.data, value)
+		wal:          *wal,
+		compactEvery: *compactEvery,
 	}
-}
 
-// Contains проверяет, содержит ли множество указанный элемент.
-func (set *Set) Contains(value string) bool {
-	for _, v := range set.data {
-		if v == value {
-			return true
+	if *rollback {
+		if !restoreAll(cfg, os.Stdout) {
+			os.Exit(1)
 		}
+		return
 	}
-	return false
-}
 
-// Remove удаляет элемент из множества.
-func (set *Set) Remove(value string) {
-	for i, v := range set.data {
-		if v == value {
-			set.data = append(set.data[:i], set.data[i+1:]...)
-			return
+	if *setOp != "" {
+		if err := runSetOperation(*setOp, *setA, *setB, *setOut, cfg.options); err != nil {
+			fmt.Println("Ошибка:", err)
+			os.Exit(1)
 		}
+		return
 	}
-}
-
-// Push добавляет элемент на вершину стека.
-func (stack *Stack) Push(value string) {
-	node := &Node{data: value}
-	if stack.head == nil {
-		stack.head = node
-	} else {
-		node.next = stack.head
-		stack.head = node
-	}
-}
 
-// Pop удаляет и возвращает элемент с вершины стека.
-func (stack *Stack) Pop() (string, error) {
-	if stack.head == nil {
-		return "", errors.New("стек пуст")
+	data := newDataSet(*hashTableSize, *hashTableMaxSize)
+	if *script != "" {
+		os.Exit(runScript(*script, cfg, data, os.Stdout, os.Stderr))
 	}
-	value := stack.head.data
-	stack.head = stack.head.next
-	return value, nil
-}
-
-// Enqueue добавляет элемент в конец очереди.
-func (queue *Queue) Enqueue(value string) {
-	node := &Node{data: value}
-	if queue.head == nil {
-		queue.head = node
-		queue.tail = node
-	} else {
-		queue.tail.next = node
-		queue.tail = node
+	if flag.NArg() > 0 {
+		os.Exit(runCommand(flag.Args(), cfg, data, os.Stdout, os.Stderr))
 	}
-}
 
-// Dequeue извлекает элемент из начала очереди и возвращает его значение.
-func (queue *Queue) Dequeue() (string, error) {
-	if queue.head == nil {
-		return "", errors.New("очередь пуста")
-	}
-	value := queue.head.data
-	queue.head = queue.head.next
-	// Если после извлечения элемента очередь осталась пустой, обновляем указатель на хвост.
-	if queue.head == nil {
-		queue.tail = nil
+	if !loadAll(data, cfg, os.Stdout) {
+		fmt.Println("Файлы не изменены. Исправьте их или восстановите из резервных копий флагом -rollback.")
+		os.Exit(1)
 	}
-	return value, nil
-}
-
-// NewHashTable создает новую хеш-таблицу.
-func NewHashTable(size int) *HashTable {
-	return &HashTable{data: make([]hashTableEntry, size)}
-}
-
-// Put добавляет пару ключ:значение в хеш-таблицу.
-func (ht *HashTable) Put(key, value string) {
-	index := hash(key, len(ht.data))
-	for i := index; i < len(ht.data); i++ {
-		if ht.data[i].key == "" {
-			ht.data[i] = hashTableEntry{key: key, value: value}
-			return
+	if cfg.wal {
+		if err := openJournals(cfg); err != nil {
+			fmt.Println("Ошибка открытия журнала:", err)
+			os.Exit(1)
 		}
+		defer closeJournals(cfg)
 	}
-}
 
-// Get возвращает значение по ключу из хеш-таблицы.
-func (ht *HashTable) Get(key string) (string, bool) {
-	index := hash(key, len(ht.data))
-	for i := index; i < len(ht.data); i++ {
-		if ht.data[i].key == key {
-			return ht.data[i].value, true
+	if *respAddr != "" || *httpAddr != "" {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := runServers(ctx, *respAddr, *httpAddr, cfg, data, os.Stdout); err != nil {
+			fmt.Println("Ошибка сервера:", err)
+			closeJournals(cfg)
+			os.Exit(1)
 		}
+		return
 	}
-	return "", false
-}
 
-// Delete удаляет запись из хеш-таблицы по ключу.
-func (ht *HashTable) Delete(key string) {
-	index := hash(key, len(ht.data))
-	for i := index; i < len(ht.data); i++ {
-		if ht.data[i].key == key {
-			ht.data[i] = hashTableEntry{}
-			return
-		}
-	}
+	con := newConsole(os.Stdin, os.Stdout)
+	fmt.Println("Программа для работы с данными (стек, очередь, очередь с приоритетом, двусторонняя очередь, множество, хеш-таблица)")
+	runMenu(con, cfg, data)
 }
 
-func hash(key string, size int) int {
-	hashVal := 0
-	for i := 0; i < len(key); i++ {
-		hashVal = (31*hashVal + int(key[i])) % size
-	}
-	return hashVal
+// console — источник ввода и получатель вывода интерактивного меню.
+// Меню не обращаются к os.Stdin напрямую, поэтому их можно запускать
+// с любым io.Reader, например strings.Reader.
+type console struct {
+	in  *bufio.Reader
+	out io.Writer
 }
 
-func main() {
-	stackFile := flag.String("stack", "stack.txt", "Файл для стека")
-	queueFile := flag.String("queue", "queue.txt", "Файл для очереди")
-	setFile := flag.String("set", "set.txt", "Файл для множества")
-	tableFile := flag.String("table", "hash_table.txt", "Файл для хеш-таблицы")
-	hashTableSize := flag.Int("table-size", 100, "Размер хеш-таблицы")
-
-	flag.Parse()
-
-	stack := &Stack{}
-	queue := &Queue{}
-	set := NewSet()
-	hashTable := NewHashTable(*hashTableSize)
-
-	if err := loadStackFromFile(stack, *stackFile); err != nil {
-		fmt.Println("Ошибка загрузки данных стека:", err)
-	}
-
-	if err := loadQueueFromFile(queue, *queueFile); err != nil {
-		fmt.Println("Ошибка загрузки данных очереди:", err)
-	}
+func newConsole(in io.Reader, out io.Writer) *console {
+	return &console{in: bufio.NewReader(in), out: out}
+}
 
-	if err := loadSetFromFile(set, *setFile); err != nil {
-		fmt.Println("Ошибка загрузки данных множества:", err)
+// readLine читает строку ввода без начальных и конечных пробелов.
+func (con *console) readLine() (string, error) {
+	line, err := con.in.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
 	}
+	return strings.TrimSpace(line), err
+}
 
-	if err := loadHashTableFromFile(hashTable, *tableFile); err != nil {
-		fmt.Println("Ошибка загрузки данных хеш-таблицы:", err)
+// readChoice выводит приглашение и читает номер пункта меню.
+func (con *console) readChoice() (int, error) {
+	fmt.Fprint(con.out, "Выберите опцию: ")
+	line, err := con.readLine()
+	if err != nil {
+		return 0, err
 	}
+	return strconv.Atoi(line)
+}
 
-	fmt.Println("Программа для работы с данными (стек, очередь, множество, хеш-таблица)")
+// runMenu выполняет главное меню до выбора выхода или конца ввода, после
+// чего сохраняет все структуры.
+func runMenu(con *console, cfg *storageConfig, data *dataSet) {
 	for {
-		fmt.Println("\nМеню:")
-		fmt.Println("1. Работа со стеком")
-		fmt.Println("2. Работа с очередью")
-		fmt.Println("3. Работа с множеством")
-		fmt.Println("4. Работа с хеш-таблицей")
-		fmt.Println("5. Выход")
-
-		fmt.Print("Выберите опцию: ")
-
-		var choice int
-		_, err := fmt.Scanln(&choice)
-		if err != nil {
-			fmt.Println("Ошибка ввода:", err)
+		fmt.Fprintln(con.out, "\nМеню:")
+		fmt.Fprintln(con.out, "1. Работа со стеком")
+		fmt.Fprintln(con.out, "2. Работа с очередью")
+		fmt.Fprintln(con.out, "3. Работа с множеством")
+		fmt.Fprintln(con.out, "4. Работа с хеш-таблицей")
+		fmt.Fprintln(con.out, "5. Работа с очередью с приоритетом")
+		fmt.Fprintln(con.out, "6. Работа с двусторонней очередью")
+		fmt.Fprintln(con.out, "7. Выход")
+
+		choice, err := con.readChoice()
+		if err == io.EOF {
+			choice = 7
+		} else if err != nil {
+			fmt.Fprintln(con.out, "Ошибка ввода:", err)
 			continue
 		}
 
 		switch choice {
 		case 1:
-			handleStackMenu(stack)
+			handleStackMenu(con, data.stack, cfg)
 		case 2:
-			handleQueueMenu(queue)
+			handleQueueMenu(con, data.queue, cfg)
 		case 3:
-			handleSetMenu(set)
-
-			if err := saveSetToFile(set, *setFile); err != nil {
-				fmt.Println("Ошибка сохранения данных множества:", err)
-			}
-
+			handleSetMenu(con, data.set, cfg)
 		case 4:
-			handleHashTableMenu(hashTable, tableFile)
-
-			if err := saveHashTableToFile(hashTable, *tableFile); err != nil {
-				fmt.Println("Ошибка сохранения данных хеш-таблицы:", err)
-			}
+			handleHashTableMenu(con, data.hashTable, cfg)
 		case 5:
-			if err := saveStackToFile(stack, *stackFile); err != nil {
-				fmt.Println("Ошибка сохранения данных стека:", err)
-			}
-
-			if err := saveQueueToFile(queue, *queueFile); err != nil {
-				fmt.Println("Ошибка сохранения данных очереди:", err)
-			}
-
-			if err := saveSetToFile(set, *setFile); err != nil {
-				fmt.Println("Ошибка сохранения данных множества:", err)
-			}
-
-			if err := saveHashTableToFile(hashTable, *tableFile); err != nil {
-				fmt.Println("Ошибка сохранения данных хеш-таблицы:", err)
-			}
-
-			fmt.Println("Выход из программы.")
+			handlePriorityQueueMenu(con, data.priorityQueue, cfg)
+		case 6:
+			handleDequeMenu(con, data.deque, cfg)
+		case 7:
+			saveAll(data, cfg, con.out)
+			fmt.Fprintln(con.out, "Выход из программы.")
 			return
 		default:
-			fmt.Println("Некорректный выбор. Попробуйте ещё раз.")
+			fmt.Fprintln(con.out, "Некорректный выбор. Попробуйте ещё раз.")
 		}
 	}
 }
 
-func handleStackMenu(stack *Stack) {
-	reader := bufio.NewReader(os.Stdin)
-
+func handleStackMenu(con *console, stack *collections.Stack[string], cfg *storageConfig) {
 	for {
-		fmt.Println("\nМеню стека:")
-		fmt.Println("1. Добавить элемент")
-		fmt.Println("2. Извлечь элемент")
-		fmt.Println("3. Вернуться в главное меню")
-
-		fmt.Print("Выберите опцию: ")
-		var choice int
-		_, err := fmt.Scanln(&choice)
-		if err != nil {
-			fmt.Println("Ошибка ввода:", err)
+		fmt.Fprintln(con.out, "\nМеню стека:")
+		fmt.Fprintln(con.out, "1. Добавить элемент")
+		fmt.Fprintln(con.out, "2. Извлечь элемент")
+		fmt.Fprintln(con.out, "3. Вернуться в главное меню")
+
+		choice, err := con.readChoice()
+		if err == io.EOF {
+			return
+		} else if err != nil {
+			fmt.Fprintln(con.out, "Ошибка ввода:", err)
 			continue
 		}
 
 		switch choice {
 		case 1:
-			fmt.Print("Введите элемент для добавления: ")
-			value, _ := reader.ReadString('\n')
-			value = strings.TrimSpace(value)
-			value = strings.TrimSuffix(value, "\n") // Удаление символа новой строки
+			fmt.Fprint(con.out, "Введите элемент для добавления: ")
+			value, _ := con.readLine()
 			stack.Push(value)
-			fmt.Println("Элемент добавлен в стек.")
+			fmt.Fprintln(con.out, "Элемент добавлен в стек.")
 
 			// Сохранение стека в файл после добавления элемента
-			if err := saveStackToFile(stack, "stack.txt"); err != nil {
-				fmt.Println("Ошибка сохранения данных стека:", err)
+			if err := cfg.recordStack(stack, storage.OpPush, value); err != nil {
+				fmt.Fprintln(con.out, "Ошибка сохранения данных стека:", err)
 			}
 		case 2:
 			value, err := stack.Pop()
 			if err != nil {
-				fmt.Println("Ошибка:", err)
+				fmt.Fprintln(con.out, "Ошибка:", err)
 			} else {
-				fmt.Println("Извлеченный элемент:", value)
+				fmt.Fprintln(con.out, "Извлеченный элемент:", value)
 
 				// После извлечения элемента из стека, обновите файл с данными стека
-				if err := saveStackToFile(stack, "stack.txt"); err != nil {
-					fmt.Println("Ошибка сохранения данных стека:", err)
+				if err := cfg.recordStack(stack, storage.OpPop); err != nil {
+					fmt.Fprintln(con.out, "Ошибка сохранения данных стека:", err)
 				}
 			}
 		case 3:
 			return
 		default:
-			fmt.Println("Некорректный выбор. Попробуйте ещё раз.")
+			fmt.Fprintln(con.out, "Некорректный выбор. Попробуйте ещё раз.")
 		}
 	}
 }
 
-func handleQueueMenu(queue *Queue) {
-	reader := bufio.NewReader(os.Stdin)
-
+func handleQueueMenu(con *console, queue *collections.Queue[string], cfg *storageConfig) {
 	for {
-		fmt.Println("\nМеню очереди:")
-		fmt.Println("1. Добавить элемент")
-		fmt.Println("2. Извлечь элемент")
-		fmt.Println("3. Вернуться в главное меню")
-
-		fmt.Print("Выберите опцию: ")
-		var choice int
-		_, err := fmt.Scanln(&choice)
-		if err != nil {
-			fmt.Println("Ошибка ввода:", err)
+		fmt.Fprintln(con.out, "\nМеню очереди:")
+		fmt.Fprintln(con.out, "1. Добавить элемент")
+		fmt.Fprintln(con.out, "2. Извлечь элемент")
+		fmt.Fprintln(con.out, "3. Вернуться в главное меню")
+
+		choice, err := con.readChoice()
+		if err == io.EOF {
+			return
+		} else if err != nil {
+			fmt.Fprintln(con.out, "Ошибка ввода:", err)
 			continue
 		}
 		switch choice {
 		case 1:
-			fmt.Print("Введите элемент для добавления: ")
-			value, _ := reader.ReadString('\n')
-			value = strings.TrimSpace(value)
+			fmt.Fprint(con.out, "Введите элемент для добавления: ")
+			value, _ := con.readLine()
 			queue.Enqueue(value)
-			fmt.Println("Элемент добавлен в очередь.")
+			fmt.Fprintln(con.out, "Элемент добавлен в очередь.")
 
 			// Сохранение очереди в файл после добавления элемента
-			if err := saveQueueToFile(queue, "queue.txt"); err != nil {
-				fmt.Println("Ошибка сохранения данных очереди:", err)
+			if err := cfg.recordQueue(queue, storage.OpEnqueue, value); err != nil {
+				fmt.Fprintln(con.out, "Ошибка сохранения данных очереди:", err)
 			}
 		case 2:
 			value, err := queue.Dequeue()
 			if err != nil {
-				fmt.Println("Ошибка:", err)
+				fmt.Fprintln(con.out, "Ошибка:", err)
 			} else {
-				fmt.Println("Извлеченный элемент:", value)
+				fmt.Fprintln(con.out, "Извлеченный элемент:", value)
 
 				// После извлечения элемента из очереди, обновите файл с данными очереди
-				if err := saveQueueToFile(queue, "queue.txt"); err != nil {
-					fmt.Println("Ошибка сохранения данных очереди:", err)
+				if err := cfg.recordQueue(queue, storage.OpDequeue); err != nil {
+					fmt.Fprintln(con.out, "Ошибка сохранения данных очереди:", err)
 				}
 			}
 		case 3:
 			return
 		default:
-			fmt.Println("Некорректный выбор. Попробуйте ещё раз.")
+			fmt.Fprintln(con.out, "Некорректный выбор. Попробуйте ещё раз.")
 		}
 	}
 }
 
-func handleSetMenu(set *Set) {
-	reader := bufio.NewReader(os.Stdin)
-
+func handlePriorityQueueMenu(con *console, pq *collections.PriorityQueue[string], cfg *storageConfig) {
 	for {
-		fmt.Println("\nМеню множества:")
-		fmt.Println("1. Добавить элемент")
-		fmt.Println("2. Проверить наличие элемента")
-		fmt.Println("3. Удалить элемент")
-		fmt.Println("4. Вернуться в главное меню")
-
-		fmt.Print("Выберите опцию: ")
-		var choice int
-		_, err := fmt.Scanln(&choice)
-		if err != nil {
-			fmt.Println("Ошибка ввода:", err)
+		fmt.Fprintln(con.out, "\nМеню очереди с приоритетом:")
+		fmt.Fprintln(con.out, "1. Добавить элемент")
+		fmt.Fprintln(con.out, "2. Извлечь элемент")
+		fmt.Fprintln(con.out, "3. Посмотреть первый элемент")
+		fmt.Fprintln(con.out, "4. Изменить приоритет элемента")
+		fmt.Fprintln(con.out, "5. Вернуться в главное меню")
+
+		choice, err := con.readChoice()
+		if err == io.EOF {
+			return
+		} else if err != nil {
+			fmt.Fprintln(con.out, "Ошибка ввода:", err)
 			continue
 		}
 
 		switch choice {
 		case 1:
-			fmt.Print("Введите элемент для добавления: ")
-			value, _ := reader.ReadString('\n')
-			value = strings.TrimSpace(value)
-			if set.Contains(value) {
-				fmt.Println("Ошибка: Вы указали существующий элемент.")
-			} else {
-				set.Add(value)
-				fmt.Println("Элемент добавлен в множество.")
+			fmt.Fprint(con.out, "Введите элемент для добавления: ")
+			value, _ := con.readLine()
+			priority, ok := readPriority(con)
+			if !ok {
+				continue
+			}
+			pq.Push(value, priority)
+			fmt.Fprintln(con.out, "Элемент добавлен в очередь с приоритетом.")
 
-				// Сохранение данных множества в файл после добавления элемента
-				if err := saveSetToFile(set, "set.txt"); err != nil {
-					fmt.Println("Ошибка сохранения данных множества:", err)
-				}
+			if err := cfg.recordPriorityQueue(pq, storage.OpPush, value, strconv.Itoa(priority)); err != nil {
+				fmt.Fprintln(con.out, "Ошибка сохранения данных очереди с приоритетом:", err)
 			}
 		case 2:
-			fmt.Print("Введите элемент для проверки: ")
-			valueToCheck, _ := reader.ReadString('\n')
-			valueToCheck = strings.TrimSpace(valueToCheck)
-
-			if set.Contains(valueToCheck) {
-				fmt.Println("Элемент найден в множестве.")
+			value, err := pq.Pop()
+			if err != nil {
+				fmt.Fprintln(con.out, "Ошибка:", err)
 			} else {
-				fmt.Println("Элемент не найден в множестве.")
-			}
+				fmt.Fprintln(con.out, "Извлеченный элемент:", value)
 
-		case 3:
-			fmt.Print("Введите элемент для удаления: ")
-			valueToDelete, _ := reader.ReadString('\n')
-			valueToDelete = strings.TrimSpace(valueToDelete)
-			if set.Contains(valueToDelete) {
-				set.Remove(valueToDelete)
-				fmt.Println("Элемент удален из множества.")
-
-				// Удаление элемента из файла множества после удаления из множества
-				if err := deleteElementFromFile("set.txt", valueToDelete); err != nil {
-					fmt.Println("Ошибка удаления элемента из файла множества:", err)
+				if err := cfg.recordPriorityQueue(pq, storage.OpPop); err != nil {
+					fmt.Fprintln(con.out, "Ошибка сохранения данных очереди с приоритетом:", err)
 				}
+			}
+		case 3:
+			value, err := pq.Peek()
+			if err != nil {
+				fmt.Fprintln(con.out, "Ошибка:", err)
 			} else {
-				fmt.Println("Ошибка: Элемент не найден в множестве.")
+				fmt.Fprintln(con.out, "Первый элемент:", value)
 			}
-			// Ваш код удаления элемента из множества
-
 		case 4:
+			fmt.Fprint(con.out, "Введите элемент: ")
+			value, _ := con.readLine()
+			priority, ok := readPriority(con)
+			if !ok {
+				continue
+			}
+			if !pq.UpdatePriority(value, priority) {
+				fmt.Fprintln(con.out, "Ошибка: Элемент не найден в очереди с приоритетом.")
+				continue
+			}
+			fmt.Fprintln(con.out, "Приоритет элемента изменён.")
+
+			if err := cfg.recordPriorityQueue(pq, storage.OpUpdate, value, strconv.Itoa(priority)); err != nil {
+				fmt.Fprintln(con.out, "Ошибка сохранения данных очереди с приоритетом:", err)
+			}
+		case 5:
 			return
 		default:
-			fmt.Println("Некорректный выбор. Попробуйте ещё раз.")
+			fmt.Fprintln(con.out, "Некорректный выбор. Попробуйте ещё раз.")
 		}
 	}
 }
 
-func handleHashTableMenu(hashTable *HashTable, tableFile *string) {
-	reader := bufio.NewReader(os.Stdin)
-
+func handleDequeMenu(con *console, deque *collections.Deque[string], cfg *storageConfig) {
 	for {
-		fmt.Println("\nМеню хеш-таблицы:")
-		fmt.Println("1. Добавить элемент")
-		fmt.Println("2. Удалить элемент")
-		fmt.Println("3. Прочитать элемент")
-		fmt.Println("4. Вернуться в главное меню")
-
-		fmt.Print("Выберите опцию: ")
-		var choice int
-		_, err := fmt.Scanln(&choice)
-		if err != nil {
-			fmt.Println("Ошибка ввода:", err)
+		fmt.Fprintln(con.out, "\nМеню двусторонней очереди:")
+		fmt.Fprintln(con.out, "1. Добавить элемент в начало")
+		fmt.Fprintln(con.out, "2. Добавить элемент в конец")
+		fmt.Fprintln(con.out, "3. Извлечь элемент из начала")
+		fmt.Fprintln(con.out, "4. Извлечь элемент из конца")
+		fmt.Fprintln(con.out, "5. Посмотреть первый и последний элементы")
+		fmt.Fprintln(con.out, "6. Вернуться в главное меню")
+
+		choice, err := con.readChoice()
+		if err == io.EOF {
+			return
+		} else if err != nil {
+			fmt.Fprintln(con.out, "Ошибка ввода:", err)
 			continue
 		}
 
 		switch choice {
-		case 1:
-			fmt.Print("Введите ключ для добавления: ")
-			key, _ := reader.ReadString('\n')
-			key = strings.TrimSpace(key)
-			if _, found := hashTable.Get(key); found {
-				fmt.Println("Ошибка: Вы указали существующий ключ.")
+		case 1, 2:
+			fmt.Fprint(con.out, "Введите элемент для добавления: ")
+			value, _ := con.readLine()
+			op := storage.OpPushFront
+			if choice == 1 {
+				deque.PushFront(value)
 			} else {
-				fmt.Print("Введите значение для добавления: ")
-				value, _ := reader.ReadString('\n')
-				value = strings.TrimSpace(value)
-				hashTable.Put(key, value)
-				fmt.Println("Элемент добавлен в хеш-таблицу.")
-
-				// Сохранение данных хеш-таблицы в файл после добавления элемента
-				if err := saveHashTableToFile(hashTable, *tableFile); err != nil {
-					fmt.Println("Ошибка сохранения данных хеш-таблицы:", err)
-				}
+				deque.PushBack(value)
+				op = storage.OpPushBack
 			}
-		case 2:
-			fmt.Print("Введите ключ для удаления: ")
-			keyToDelete, _ := reader.ReadString('\n')
-			keyToDelete = strings.TrimSpace(keyToDelete)
-			if _, found := hashTable.Get(keyToDelete); found {
-				hashTable.Delete(keyToDelete)
-				fmt.Println("Элемент удален из хеш-таблицы.")
+			fmt.Fprintln(con.out, "Элемент добавлен в двустороннюю очередь.")
 
-				// Удаление элемента из файла хеш-таблицы после удаления из хеш-таблицы
-				if err := deleteElementFromFile(*tableFile, keyToDelete); err != nil {
-					fmt.Println("Ошибка удаления элемента из файла хеш-таблицы:", err)
-				}
-			} else {
-				fmt.Println("Ошибка: Элемент не найден в хеш-таблице.")
+			if err := cfg.recordDeque(deque, op, value); err != nil {
+				fmt.Fprintln(con.out, "Ошибка сохранения данных двусторонней очереди:", err)
 			}
-
-		case 3:
-			fmt.Print("Введите ключ для чтения: ")
-			keyToRead, _ := reader.ReadString('\n')
-			keyToRead = strings.TrimSpace(keyToRead)
-
-			value, found := hashTable.Get(keyToRead)
-			if found {
-				fmt.Printf("Значение для ключа %s: %s\n", keyToRead, value)
-			} else {
-				fmt.Println("Элемент не найден в хеш-таблице.")
+		case 3, 4:
+			pop, op := deque.PopFront, storage.OpPopFront
+			if choice == 4 {
+				pop, op = deque.PopBack, storage.OpPopBack
 			}
+			value, err := pop()
+			if err != nil {
+				fmt.Fprintln(con.out, "Ошибка:", err)
+			} else {
+				fmt.Fprintln(con.out, "Извлеченный элемент:", value)
 
-		case 4:
+				if err := cfg.recordDeque(deque, op); err != nil {
+					fmt.Fprintln(con.out, "Ошибка сохранения данных двусторонней очереди:", err)
+				}
+			}
+		case 5:
+			front, err := deque.PeekFront()
+			if err != nil {
+				fmt.Fprintln(con.out, "Ошибка:", err)
+				continue
+			}
+			back, _ := deque.PeekBack()
+			fmt.Fprintln(con.out, "Первый элемент:", front)
+			fmt.Fprintln(con.out, "Последний элемент:", back)
+		case 6:
 			return
 		default:
-			fmt.Println("Некорректный выбор. Попробуйте ещё раз.")
+			fmt.Fprintln(con.out, "Некорректный выбор. Попробуйте ещё раз.")
 		}
 	}
 }
 
-// Функция для сохранения данных стека в файл
-func saveStackToFile(stack *Stack, filename string) error {
-	file, err := os.Create(filename)
+// readPriority запрашивает приоритет элемента. При неверном вводе выводит
+// ошибку и возвращает false.
+func readPriority(con *console) (int, bool) {
+	fmt.Fprint(con.out, "Введите приоритет (больше — раньше): ")
+	line, _ := con.readLine()
+	priority, err := strconv.Atoi(line)
 	if err != nil {
-		return err
+		fmt.Fprintln(con.out, "Ошибка: приоритет должен быть целым числом.")
+		return 0, false
 	}
-	defer file.Close()
-
-	current := stack.head
-	for current != nil {
-		_, err := fmt.Fprintln(file, current.data)
-		if err != nil {
-			return err
-		}
-		current = current.next
-	}
-
-	return nil
-}
-
-// Функция для сохранения данных очереди в файл
-func saveQueueToFile(queue *Queue, filename string) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	current := queue.head
-	for current != nil {
-		_, err := fmt.Fprintln(file, current.data)
-		if err != nil {
-			return err
-		}
-		current = current.next
-	}
-
-	return nil
+	return priority, true
 }
 
-// Функция для сохранения данных множества в файл
-func saveSetToFile(set *Set, filename string) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	for _, value := range set.data {
-		_, err := fmt.Fprintln(file, value)
-		if err != nil {
-			return err
+func handleSetMenu(con *console, set *collections.Set[string], cfg *storageConfig) {
+	for {
+		fmt.Fprintln(con.out, "\nМеню множества:")
+		fmt.Fprintln(con.out, "1. Добавить элемент")
+		fmt.Fprintln(con.out, "2. Проверить наличие элемента")
+		fmt.Fprintln(con.out, "3. Удалить элемент")
+		fmt.Fprintln(con.out, "4. Вернуться в главное меню")
+
+		choice, err := con.readChoice()
+		if err == io.EOF {
+			return
+		} else if err != nil {
+			fmt.Fprintln(con.out, "Ошибка ввода:", err)
+			continue
 		}
-	}
-
-	return nil
-}
 
-// Функция для сохранения данных хеш-таблицы в файл
-func saveHashTableToFile(hashTable *HashTable, filename string) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
+		switch choice {
+		case 1:
+			fmt.Fprint(con.out, "Введите элемент для добавления: ")
+			value, _ := con.readLine()
+			if set.Contains(value) {
+				fmt.Fprintln(con.out, "Ошибка: Вы указали существующий элемент.")
+			} else {
+				set.Add(value)
+				fmt.Fprintln(con.out, "Элемент добавлен в множество.")
 
-	for _, entry := range hashTable.data {
-		if entry.key != "" {
-			_, err := fmt.Fprintf(file, "%s:%s\n", entry.key, entry.value)
-			if err != nil {
-				return err
+				// Сохранение данных множества в файл после добавления элемента
+				if err := cfg.recordSet(set, storage.OpAdd, value); err != nil {
+					fmt.Fprintln(con.out, "Ошибка сохранения данных множества:", err)
+				}
 			}
-		}
-	}
-
-	return nil
-}
-
-// Функция для загрузки данных стека из файла
-func loadStackFromFile(stack *Stack, filename string) error {
-	file, err := os.Open(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		stack.Push(line)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// Функция для загрузки данных множества из файла
-func loadSetFromFile(set *Set, filename string) error {
-	file, err := os.Open(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		set.Add(line)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return err
-	}
+		case 2:
+			fmt.Fprint(con.out, "Введите элемент для проверки: ")
+			valueToCheck, _ := con.readLine()
 
-	return nil
-}
+			if set.Contains(valueToCheck) {
+				fmt.Fprintln(con.out, "Элемент найден в множестве.")
+			} else {
+				fmt.Fprintln(con.out, "Элемент не найден в множестве.")
+			}
 
-// Функция для загрузки данных очереди из файла
-func loadQueueFromFile(queue *Queue, filename string) error {
-	file, err := os.Open(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
+		case 3:
+			fmt.Fprint(con.out, "Введите элемент для удаления: ")
+			valueToDelete, _ := con.readLine()
+			if set.Contains(valueToDelete) {
+				set.Remove(valueToDelete)
+				fmt.Fprintln(con.out, "Элемент удален из множества.")
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		queue.Enqueue(line)
-	}
+				// Перезапись файла множества после удаления элемента
+				if err := cfg.recordSet(set, storage.OpRemove, valueToDelete); err != nil {
+					fmt.Fprintln(con.out, "Ошибка сохранения данных множества:", err)
+				}
+			} else {
+				fmt.Fprintln(con.out, "Ошибка: Элемент не найден в множестве.")
+			}
+			// Ваш код удаления элемента из множества
 
-	if err := scanner.Err(); err != nil {
-		return err
+		case 4:
+			return
+		default:
+			fmt.Fprintln(con.out, "Некорректный выбор. Попробуйте ещё раз.")
+		}
 	}
-
-	return nil
 }
 
-// Функция для загрузки данных хеш-таблицы из файла
-func loadHashTableFromFile(hashTable *HashTable, filename string) error {
-	file, err := os.Open(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		parts := strings.SplitN(line, ":", 2) // Используем SplitN для разделения только по первому символу ":"
-		if len(parts) == 2 {
-			key := parts[0]
-			value := parts[1]
-			hashTable.Put(key, value)
+func handleHashTableMenu(con *console, hashTable *collections.HashTable[string, string], cfg *storageConfig) {
+	for {
+		fmt.Fprintln(con.out, "\nМеню хеш-таблицы:")
+		fmt.Fprintln(con.out, "1. Добавить или обновить элемент")
+		fmt.Fprintln(con.out, "2. Добавить элемент, если ключа нет")
+		fmt.Fprintln(con.out, "3. Заменить значение существующего ключа")
+		fmt.Fprintln(con.out, "4. Сравнить и заменить значение")
+		fmt.Fprintln(con.out, "5. Удалить элемент")
+		fmt.Fprintln(con.out, "6. Прочитать элемент")
+		fmt.Fprintln(con.out, "7. Вернуться в главное меню")
+
+		choice, err := con.readChoice()
+		if err == io.EOF {
+			return
+		} else if err != nil {
+			fmt.Fprintln(con.out, "Ошибка ввода:", err)
+			continue
 		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return err
-	}
 
-	return nil
-}
+		switch choice {
+		case 1:
+			fmt.Fprint(con.out, "Введите ключ: ")
+			key, _ := con.readLine()
+			fmt.Fprint(con.out, "Введите значение: ")
+			value, _ := con.readLine()
+			updated, err := hashTable.Put(key, value)
+			if err != nil {
+				fmt.Fprintln(con.out, "Ошибка:", err)
+				continue
+			}
+			if updated {
+				fmt.Fprintln(con.out, "Значение ключа обновлено.")
+			} else {
+				fmt.Fprintln(con.out, "Элемент добавлен в хеш-таблицу.")
+			}
 
-func deleteElementFromFile(filename, elementToDelete string) error {
-	// Чтение содержимого файла в массив строк
-	lines, err := readLinesFromFile(filename)
-	if err != nil {
-		return err
-	}
+			// Сохранение данных хеш-таблицы в файл после изменения
+			if err := cfg.recordHashTable(hashTable, storage.OpPut, key, value); err != nil {
+				fmt.Fprintln(con.out, "Ошибка сохранения данных хеш-таблицы:", err)
+			}
+		case 2:
+			fmt.Fprint(con.out, "Введите ключ: ")
+			key, _ := con.readLine()
+			fmt.Fprint(con.out, "Введите значение: ")
+			value, _ := con.readLine()
+			actual, inserted, err := hashTable.PutIfAbsent(key, value)
+			if err != nil {
+				fmt.Fprintln(con.out, "Ошибка:", err)
+				continue
+			}
+			if !inserted {
+				fmt.Fprintf(con.out, "Ключ уже существует, текущее значение: %s\n", actual)
+				continue
+			}
+			fmt.Fprintln(con.out, "Элемент добавлен в хеш-таблицу.")
 
-	// Поиск элемента в массиве строк и удаление его, если он существует
-	for i, line := range lines {
-		if line == elementToDelete {
-			lines = append(lines[:i], lines[i+1:]...)
-			break
-		}
-	}
+			if err := cfg.recordHashTable(hashTable, storage.OpPut, key, value); err != nil {
+				fmt.Fprintln(con.out, "Ошибка сохранения данных хеш-таблицы:", err)
+			}
+		case 3:
+			fmt.Fprint(con.out, "Введите ключ: ")
+			key, _ := con.readLine()
+			fmt.Fprint(con.out, "Введите новое значение: ")
+			value, _ := con.readLine()
+			previous, found := hashTable.Replace(key, value)
+			if !found {
+				fmt.Fprintln(con.out, "Ошибка: Элемент не найден в хеш-таблице.")
+				continue
+			}
+			fmt.Fprintf(con.out, "Значение заменено, прежнее значение: %s\n", previous)
 
-	// Запись обновленных данных обратно в файл
-	err = writeLinesToFile(filename, lines)
-	if err != nil {
-		return err
-	}
+			if err := cfg.recordHashTable(hashTable, storage.OpPut, key, value); err != nil {
+				fmt.Fprintln(con.out, "Ошибка сохранения данных хеш-таблицы:", err)
+			}
+		case 4:
+			fmt.Fprint(con.out, "Введите ключ: ")
+			key, _ := con.readLine()
+			fmt.Fprint(con.out, "Введите ожидаемое значение: ")
+			old, _ := con.readLine()
+			fmt.Fprint(con.out, "Введите новое значение: ")
+			value, _ := con.readLine()
+			if !hashTable.CompareAndSwap(key, old, value) {
+				fmt.Fprintln(con.out, "Замена не выполнена: ключ не найден или значение отличается.")
+				continue
+			}
+			fmt.Fprintln(con.out, "Значение заменено.")
 
-	return nil
-}
-func readLinesFromFile(filename string) ([]string, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
+			if err := cfg.recordHashTable(hashTable, storage.OpPut, key, value); err != nil {
+				fmt.Fprintln(con.out, "Ошибка сохранения данных хеш-таблицы:", err)
+			}
+		case 5:
+			fmt.Fprint(con.out, "Введите ключ для удаления: ")
+			keyToDelete, _ := con.readLine()
+			if _, found := hashTable.Get(keyToDelete); found {
+				hashTable.Delete(keyToDelete)
+				fmt.Fprintln(con.out, "Элемент удален из хеш-таблицы.")
 
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
+				// Перезапись файла хеш-таблицы после удаления записи
+				if err := cfg.recordHashTable(hashTable, storage.OpDelete, keyToDelete); err != nil {
+					fmt.Fprintln(con.out, "Ошибка сохранения данных хеш-таблицы:", err)
+				}
+			} else {
+				fmt.Fprintln(con.out, "Ошибка: Элемент не найден в хеш-таблице.")
+			}
 
-	return lines, scanner.Err()
-}
+		case 6:
+			fmt.Fprint(con.out, "Введите ключ для чтения: ")
+			keyToRead, _ := con.readLine()
 
-// Функция для записи массива строк в файл
-func writeLinesToFile(filename string, lines []string) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
+			value, found := hashTable.Get(keyToRead)
+			if found {
+				fmt.Fprintf(con.out, "Значение для ключа %q: %s\n", keyToRead, value)
+			} else {
+				fmt.Fprintln(con.out, "Элемент не найден в хеш-таблице.")
+			}
 
-	for _, line := range lines {
-		_, err := fmt.Fprintln(file, line)
-		if err != nil {
-			return err
+		case 7:
+			return
+		default:
+			fmt.Fprintln(con.out, "Некорректный выбор. Попробуйте ещё раз.")
 		}
 	}
-
-	return nil
 }
-
-// Функция для чтения строк из файла и возврата их в виде массива
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/semishida/Laba1/collections"
+	"github.com/semishida/Laba1/storage"
+)
+
+// newTestConfig возвращает конфигурацию, в которой все файлы лежат во
+// временном каталоге.
+func newTestConfig(t *testing.T) *storageConfig {
+	dir := t.TempDir()
+	return &storageConfig{
+		stackFile:         filepath.Join(dir, "custom_stack.txt"),
+		queueFile:         filepath.Join(dir, "queue.txt"),
+		priorityQueueFile: filepath.Join(dir, "priority_queue.txt"),
+		dequeFile:         filepath.Join(dir, "deque.txt"),
+		setFile:           filepath.Join(dir, "set.txt"),
+		tableFile:         filepath.Join(dir, "hash_table.txt"),
+	}
+}
+
+func TestMenuUsesConfiguredStackFile(t *testing.T) {
+	cfg := newTestConfig(t)
+	data := newDataSet(8, 0)
+	t.Chdir(t.TempDir())
+
+	// Добавляем два элемента, извлекаем один и выходим из программы.
+	input := strings.NewReader("1\n1\nfirst\n1\nsecond\n2\n3\n7\n")
+	var out strings.Builder
+	runMenu(newConsole(input, &out), cfg, data)
+
+	if !strings.Contains(out.String(), "Извлеченный элемент: second") {
+		t.Errorf("в выводе нет извлечённого элемента:\n%s", out.String())
+	}
+	stack := collections.NewStack[string]()
+	if err := storage.LoadStack(stack, cfg.stackFile, cfg.options); err != nil {
+		t.Fatalf("LoadStack(%s): %v", cfg.stackFile, err)
+	}
+	if got, want := stack.Values(), []string{"first"}; !slices.Equal(got, want) {
+		t.Errorf("в %s стек %q, ожидалось %q", cfg.stackFile, got, want)
+	}
+	if _, err := os.Stat("stack.txt"); err == nil {
+		t.Error("меню записало файл по умолчанию stack.txt вместо настроенного пути")
+	}
+}
+
+func TestStackMenuSavesOnEachChange(t *testing.T) {
+	cfg := newTestConfig(t)
+	stack := collections.NewStack[string]()
+
+	// Ввод заканчивается без выхода: файл должен быть записан самим меню.
+	handleStackMenu(newConsole(strings.NewReader("1\nvalue\n"), io.Discard), stack, cfg)
+
+	loaded := collections.NewStack[string]()
+	if err := storage.LoadStack(loaded, cfg.stackFile, cfg.options); err != nil {
+		t.Fatalf("LoadStack: %v", err)
+	}
+	if got, want := loaded.Values(), []string{"value"}; !slices.Equal(got, want) {
+		t.Errorf("стек %q, ожидалось %q", got, want)
+	}
+}
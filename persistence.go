@@ -0,0 +1,259 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"sync"
+
+	"github.com/semishida/Laba1/collections"
+	"github.com/semishida/Laba1/storage"
+)
+
+// storageConfig содержит пути к файлам, в которых сохраняются структуры.
+// Все функции меню сохраняют данные только по этим путям.
+type storageConfig struct {
+	stackFile         string
+	queueFile         string
+	priorityQueueFile string
+	dequeFile         string
+	setFile           string
+	tableFile         string
+	options           storage.Options
+	// compactEvery — число операций в журнале, после которого файл
+	// перезаписывается целиком; 0 — только при выходе.
+	compactEvery int
+	// wal включает журнал операций (флаг -wal).
+	wal bool
+	// journals заполняются при запуске с -wal. Если журналов нет, каждое
+	// изменение сразу перезаписывает файл структуры.
+	journals journals
+}
+
+// journals — журналы операций структур.
+type journals struct {
+	stack         *storage.Journal
+	queue         *storage.Journal
+	priorityQueue *storage.Journal
+	deque         *storage.Journal
+	set           *storage.Journal
+	table         *storage.Journal
+}
+
+// dataSet объединяет структуры, с которыми работает программа.
+type dataSet struct {
+	// mu защищает структуры, когда к ним обращаются обработчики серверов.
+	mu sync.Mutex
+
+	stack         *collections.Stack[string]
+	queue         *collections.Queue[string]
+	priorityQueue *collections.PriorityQueue[string]
+	deque         *collections.Deque[string]
+	set           *collections.Set[string]
+	hashTable     *collections.HashTable[string, string]
+}
+
+// newDataSet создает пустые структуры. tableSize и tableMaxSize задают
+// начальный и максимальный размер хеш-таблицы.
+func newDataSet(tableSize, tableMaxSize int) *dataSet {
+	hashTable := collections.NewHashTable[string, string](tableSize, collections.HashString)
+	hashTable.SetMaxSize(tableMaxSize)
+	return &dataSet{
+		stack:         collections.NewStack[string](),
+		queue:         collections.NewQueue[string](),
+		priorityQueue: collections.NewPriorityQueue[string](),
+		deque:         collections.NewDeque[string](),
+		set:           collections.NewSet[string](),
+		hashTable:     hashTable,
+	}
+}
+
+// Функция для загрузки всех структур из файлов. Ошибки выводятся в out,
+// загрузка остальных структур при этом продолжается. Отсутствующий файл
+// означает пустую структуру. При любой другой ошибке возвращает false:
+// продолжать работу нельзя, иначе при выходе файл (а с -backup и его
+// резервная копия) был бы перезаписан пустой структурой.
+func loadAll(data *dataSet, cfg *storageConfig, out io.Writer) bool {
+	ok := checkLoad(out, "стека", storage.LoadStack(data.stack, cfg.stackFile, cfg.options))
+	ok = checkLoad(out, "очереди", storage.LoadQueue(data.queue, cfg.queueFile, cfg.options)) && ok
+	ok = checkLoad(out, "очереди с приоритетом", storage.LoadPriorityQueue(data.priorityQueue, cfg.priorityQueueFile, cfg.options)) && ok
+	ok = checkLoad(out, "двусторонней очереди", storage.LoadDeque(data.deque, cfg.dequeFile, cfg.options)) && ok
+	ok = checkLoad(out, "множества", storage.LoadSet(data.set, cfg.setFile, cfg.options)) && ok
+	ok = checkLoad(out, "хеш-таблицы", storage.LoadHashTable(data.hashTable, cfg.tableFile, cfg.options)) && ok
+	return ok
+}
+
+// checkLoad выводит ошибку загрузки структуры what и сообщает, можно ли
+// продолжать работу: отсутствие файла ошибкой не считается.
+func checkLoad(out io.Writer, what string, err error) bool {
+	if err == nil {
+		return true
+	}
+	fmt.Fprintf(out, "Ошибка загрузки данных %s: %v\n", what, err)
+	return errors.Is(err, fs.ErrNotExist)
+}
+
+// Функция для сохранения всех структур в файлы. Журналы при этом сжимаются.
+// Ошибки выводятся в out; возвращает false, если хотя бы одна структура не
+// сохранилась.
+func saveAll(data *dataSet, cfg *storageConfig, out io.Writer) bool {
+	ok := true
+	if err := cfg.flush(cfg.journals.stack, cfg.stackSaver(data.stack)); err != nil {
+		fmt.Fprintln(out, "Ошибка сохранения данных стека:", err)
+		ok = false
+	}
+
+	if err := cfg.flush(cfg.journals.queue, cfg.queueSaver(data.queue)); err != nil {
+		fmt.Fprintln(out, "Ошибка сохранения данных очереди:", err)
+		ok = false
+	}
+
+	if err := cfg.flush(cfg.journals.priorityQueue, cfg.priorityQueueSaver(data.priorityQueue)); err != nil {
+		fmt.Fprintln(out, "Ошибка сохранения данных очереди с приоритетом:", err)
+		ok = false
+	}
+
+	if err := cfg.flush(cfg.journals.deque, cfg.dequeSaver(data.deque)); err != nil {
+		fmt.Fprintln(out, "Ошибка сохранения данных двусторонней очереди:", err)
+		ok = false
+	}
+
+	if err := cfg.flush(cfg.journals.set, cfg.setSaver(data.set)); err != nil {
+		fmt.Fprintln(out, "Ошибка сохранения данных множества:", err)
+		ok = false
+	}
+
+	if err := cfg.flush(cfg.journals.table, cfg.hashTableSaver(data.hashTable)); err != nil {
+		fmt.Fprintln(out, "Ошибка сохранения данных хеш-таблицы:", err)
+		ok = false
+	}
+
+	return ok
+}
+
+// Функция для открытия журналов всех структур. Вызывается после loadAll,
+// чтобы операции из журналов уже были применены.
+func openJournals(cfg *storageConfig) error {
+	var err error
+	if cfg.journals.stack, err = storage.OpenJournal(cfg.stackFile); err != nil {
+		return err
+	}
+	if cfg.journals.queue, err = storage.OpenJournal(cfg.queueFile); err != nil {
+		return err
+	}
+	if cfg.journals.priorityQueue, err = storage.OpenJournal(cfg.priorityQueueFile); err != nil {
+		return err
+	}
+	if cfg.journals.deque, err = storage.OpenJournal(cfg.dequeFile); err != nil {
+		return err
+	}
+	if cfg.journals.set, err = storage.OpenJournal(cfg.setFile); err != nil {
+		return err
+	}
+	cfg.journals.table, err = storage.OpenJournal(cfg.tableFile)
+	return err
+}
+
+// Функция для закрытия открытых журналов.
+func closeJournals(cfg *storageConfig) {
+	for _, journal := range []*storage.Journal{cfg.journals.stack, cfg.journals.queue, cfg.journals.priorityQueue, cfg.journals.deque, cfg.journals.set, cfg.journals.table} {
+		if journal != nil {
+			journal.Close()
+		}
+	}
+	cfg.journals = journals{}
+}
+
+// record сохраняет одно изменение структуры. При включённом журнале
+// операция дописывается в него, а файл перезаписывается, только когда
+// в журнале накопилось compactEvery операций; без журнала файл сразу
+// перезаписывается функцией save.
+func (cfg *storageConfig) record(journal *storage.Journal, save func() error, op storage.Op, args ...string) error {
+	if journal == nil {
+		return save()
+	}
+	if err := journal.Append(op, args...); err != nil {
+		return err
+	}
+	if cfg.compactEvery > 0 && journal.Len() >= cfg.compactEvery {
+		return journal.Compact(save)
+	}
+	return nil
+}
+
+// flush перезаписывает файл структуры функцией save и очищает её журнал.
+func (cfg *storageConfig) flush(journal *storage.Journal, save func() error) error {
+	if journal == nil {
+		return save()
+	}
+	return journal.Compact(save)
+}
+
+func (cfg *storageConfig) recordStack(stack *collections.Stack[string], op storage.Op, args ...string) error {
+	return cfg.record(cfg.journals.stack, cfg.stackSaver(stack), op, args...)
+}
+
+func (cfg *storageConfig) recordQueue(queue *collections.Queue[string], op storage.Op, args ...string) error {
+	return cfg.record(cfg.journals.queue, cfg.queueSaver(queue), op, args...)
+}
+
+func (cfg *storageConfig) recordPriorityQueue(pq *collections.PriorityQueue[string], op storage.Op, args ...string) error {
+	return cfg.record(cfg.journals.priorityQueue, cfg.priorityQueueSaver(pq), op, args...)
+}
+
+func (cfg *storageConfig) recordDeque(deque *collections.Deque[string], op storage.Op, args ...string) error {
+	return cfg.record(cfg.journals.deque, cfg.dequeSaver(deque), op, args...)
+}
+
+func (cfg *storageConfig) recordSet(set *collections.Set[string], op storage.Op, args ...string) error {
+	return cfg.record(cfg.journals.set, cfg.setSaver(set), op, args...)
+}
+
+func (cfg *storageConfig) recordHashTable(hashTable *collections.HashTable[string, string], op storage.Op, args ...string) error {
+	return cfg.record(cfg.journals.table, cfg.hashTableSaver(hashTable), op, args...)
+}
+
+func (cfg *storageConfig) stackSaver(stack *collections.Stack[string]) func() error {
+	return func() error { return storage.SaveStack(stack, cfg.stackFile, cfg.options) }
+}
+
+func (cfg *storageConfig) queueSaver(queue *collections.Queue[string]) func() error {
+	return func() error { return storage.SaveQueue(queue, cfg.queueFile, cfg.options) }
+}
+
+func (cfg *storageConfig) priorityQueueSaver(pq *collections.PriorityQueue[string]) func() error {
+	return func() error { return storage.SavePriorityQueue(pq, cfg.priorityQueueFile, cfg.options) }
+}
+
+func (cfg *storageConfig) dequeSaver(deque *collections.Deque[string]) func() error {
+	return func() error { return storage.SaveDeque(deque, cfg.dequeFile, cfg.options) }
+}
+
+func (cfg *storageConfig) setSaver(set *collections.Set[string]) func() error {
+	return func() error { return storage.SaveSet(set, cfg.setFile, cfg.options) }
+}
+
+func (cfg *storageConfig) hashTableSaver(hashTable *collections.HashTable[string, string]) func() error {
+	return func() error { return storage.SaveHashTable(hashTable, cfg.tableFile, cfg.options) }
+}
+
+// Функция для отката всех файлов к резервным копиям. Возвращает false, если
+// хотя бы один файл восстановить не удалось.
+func restoreAll(cfg *storageConfig, out io.Writer) bool {
+	ok := true
+	for _, filename := range []string{cfg.stackFile, cfg.queueFile, cfg.priorityQueueFile, cfg.dequeFile, cfg.setFile, cfg.tableFile} {
+		err := storage.RestoreBackup(filename)
+		if errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintln(out, "Нет резервной копии для", filename)
+			continue
+		}
+		if err != nil {
+			fmt.Fprintln(out, "Ошибка восстановления:", err)
+			ok = false
+			continue
+		}
+		fmt.Fprintln(out, "Восстановлен файл", filename)
+	}
+	return ok
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxBulkLength ограничивает размер одной строки запроса RESP.
+	maxBulkLength = 512 << 20
+	// maxArrayLength ограничивает число аргументов команды, как в Redis.
+	maxArrayLength = 1024 * 1024
+	// maxLineLength ограничивает длину inline-команды и заголовков.
+	maxLineLength = 64 << 10
+)
+
+// readRESPCommand читает одну команду клиента. Поддерживаются массивы
+// bulk-строк, которые отправляют redis-cli и клиентские библиотеки, и
+// inline-команды (слова через пробел), удобные для telnet.
+func readRESPCommand(r *bufio.Reader) ([]string, error) {
+	line, err := readRESPLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return strings.Fields(line), nil
+	}
+
+	count, err := strconv.Atoi(line[1:])
+	if err != nil || count < 0 || count > maxArrayLength {
+		return nil, fmt.Errorf("неверная длина массива %q", line)
+	}
+	// Длину массива задаёт клиент, поэтому память под аргументы выделяется
+	// по мере их чтения, а не заранее.
+	var args []string
+	for i := 0; i < count; i++ {
+		header, err := readRESPLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(header, "$") {
+			return nil, fmt.Errorf("ожидалась bulk-строка, получено %q", header)
+		}
+		size, err := strconv.Atoi(header[1:])
+		if err != nil || size < 0 || size > maxBulkLength {
+			return nil, fmt.Errorf("неверная длина строки %q", header)
+		}
+		// Буфер растёт по мере поступления данных, а не по заявленной
+		// клиентом длине.
+		var buf bytes.Buffer
+		if _, err := io.CopyN(&buf, r, int64(size)+2); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		data := buf.Bytes()
+		if data[size] != '\r' || data[size+1] != '\n' {
+			return nil, errors.New("bulk-строка не завершена CRLF")
+		}
+		args = append(args, string(data[:size]))
+	}
+	return args, nil
+}
+
+// readRESPLine читает строку протокола длиной не больше maxLineLength без
+// завершающего CRLF.
+func readRESPLine(r *bufio.Reader) (string, error) {
+	var line []byte
+	for {
+		chunk, err := r.ReadSlice('\n')
+		if len(line)+len(chunk) > maxLineLength {
+			return "", fmt.Errorf("строка длиннее %d байт", maxLineLength)
+		}
+		line = append(line, chunk...)
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(strings.TrimSuffix(string(line), "\n"), "\r"), nil
+	}
+}
+
+// respWriter формирует ответы в формате RESP.
+type respWriter struct {
+	w *bufio.Writer
+}
+
+func (rw respWriter) simple(s string) {
+	fmt.Fprintf(rw.w, "+%s\r\n", s)
+}
+
+func (rw respWriter) error(msg string) {
+	fmt.Fprintf(rw.w, "-%s\r\n", strings.ReplaceAll(msg, "\r\n", " "))
+}
+
+func (rw respWriter) integer(n int) {
+	fmt.Fprintf(rw.w, ":%d\r\n", n)
+}
+
+func (rw respWriter) bulk(s string) {
+	fmt.Fprintf(rw.w, "$%d\r\n%s\r\n", len(s), s)
+}
+
+func (rw respWriter) null() {
+	rw.w.WriteString("$-1\r\n")
+}
+
+func (rw respWriter) array(items []string) {
+	fmt.Fprintf(rw.w, "*%d\r\n", len(items))
+	for _, item := range items {
+		rw.bulk(item)
+	}
+}
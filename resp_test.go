@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bufio"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadRESPCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{"массив", "*2\r\n$4\r\nPING\r\n$0\r\n\r\n", []string{"PING", ""}, false},
+		{"inline", "SADD set x\r\n", []string{"SADD", "set", "x"}, false},
+		{"слишком длинный массив", "*100000000000\r\n", nil, true},
+		{"обрезанная bulk-строка", "*1\r\n$536870912\r\nabc", nil, true},
+		{"bulk-строка без CRLF", "*1\r\n$3\r\nabcde", nil, true},
+		{"слишком длинная строка", strings.Repeat("a", maxLineLength+1) + "\r\n", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readRESPCommand(bufio.NewReader(strings.NewReader(tt.input)))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ошибка = %v, ожидалась ошибка: %t", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("аргументы = %q, ожидалось %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadRESPCommandDoesNotPreallocateBulk(t *testing.T) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	readRESPCommand(bufio.NewReader(strings.NewReader("*1\r\n$536870912\r\nabc")))
+	runtime.ReadMemStats(&after)
+
+	// Заявленные клиентом 512 МиБ не должны выделяться заранее.
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 1<<20 {
+		t.Errorf("выделено %d байт для трёх байт данных", allocated)
+	}
+}
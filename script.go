@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/semishida/Laba1/storage"
+)
+
+// Функция для выполнения файла сценария. Каждая строка сценария — одна
+// операция (PUSH a, ENQUEUE b, SADD c, HSET k v, HGET k, ...); аргументы с
+// пробелами записываются в двойных кавычках. Пустые строки и строки,
+// начинающиеся с "#", пропускаются. Результат каждой операции выводится
+// отдельной строкой в stdout, ошибки — в stderr с номером строки. После
+// выполнения всех строк структуры сохраняются. Возвращает код завершения.
+func runScript(filename string, cfg *storageConfig, data *dataSet, stdout, stderr io.Writer) int {
+	file, err := os.Open(filename)
+	if err != nil {
+		fmt.Fprintln(stderr, "Ошибка:", err)
+		return exitFailure
+	}
+	defer file.Close()
+
+	if err := loadExisting(data, cfg); err != nil {
+		fmt.Fprintln(stderr, "Ошибка:", err)
+		return exitFailure
+	}
+
+	code := exitOK
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields, err := splitScriptLine(line)
+		if err == nil {
+			var result string
+			result, err = executeScriptCommand(data, fields)
+			if err == nil {
+				fmt.Fprintln(stdout, result)
+				continue
+			}
+		}
+		fmt.Fprintf(stderr, "%s:%d: %v\n", filename, lineNumber, err)
+		code = exitNotFound
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintln(stderr, "Ошибка:", err)
+		return exitFailure
+	}
+
+	if !saveAll(data, cfg, stderr) {
+		return exitFailure
+	}
+	return code
+}
+
+// scriptArity — операции сценария и количество их аргументов.
+var scriptArity = map[string]int{
+	"PUSH": 1, "POP": 0,
+	"ENQUEUE": 1, "DEQUEUE": 0,
+	"SADD": 1, "SREM": 1, "SISMEMBER": 1,
+	"HSET": 2, "HGET": 1, "HDEL": 1,
+}
+
+// executeScriptCommand выполняет одну операцию сценария и возвращает её
+// результат.
+func executeScriptCommand(data *dataSet, fields []string) (string, error) {
+	name := strings.ToUpper(fields[0])
+	args := fields[1:]
+	count, ok := scriptArity[name]
+	if !ok {
+		return "", fmt.Errorf("неизвестная операция %s", fields[0])
+	}
+	if len(args) != count {
+		return "", fmt.Errorf("%s ожидает аргументов: %d, получено: %d", name, count, len(args))
+	}
+
+	switch name {
+	case "PUSH":
+		data.stack.Push(args[0])
+		return "OK", nil
+	case "POP":
+		return data.stack.Pop()
+	case "ENQUEUE":
+		data.queue.Enqueue(args[0])
+		return "OK", nil
+	case "DEQUEUE":
+		return data.queue.Dequeue()
+	case "SADD":
+		if data.set.Contains(args[0]) {
+			return "0", nil
+		}
+		data.set.Add(args[0])
+		return "1", nil
+	case "SREM":
+		if !data.set.Contains(args[0]) {
+			return "0", nil
+		}
+		data.set.Remove(args[0])
+		return "1", nil
+	case "SISMEMBER":
+		if data.set.Contains(args[0]) {
+			return "1", nil
+		}
+		return "0", nil
+	case "HSET":
+		if _, err := data.hashTable.Put(args[0], args[1]); err != nil {
+			return "", err
+		}
+		return "OK", nil
+	case "HGET":
+		value, found := data.hashTable.Get(args[0])
+		if !found {
+			return "", errors.New("ключ не найден")
+		}
+		return value, nil
+	default: // HDEL
+		if _, found := data.hashTable.Get(args[0]); !found {
+			return "0", nil
+		}
+		data.hashTable.Delete(args[0])
+		return "1", nil
+	}
+}
+
+// splitScriptLine делит строку сценария на слова. Слово в двойных кавычках
+// может содержать пробелы и escape-последовательности Go.
+func splitScriptLine(line string) ([]string, error) {
+	var fields []string
+	for {
+		line = strings.TrimLeft(line, " \t")
+		if line == "" {
+			return fields, nil
+		}
+		if line[0] == '"' {
+			quoted, err := strconv.QuotedPrefix(line)
+			if err != nil {
+				return nil, fmt.Errorf("незакрытая кавычка: %s", line)
+			}
+			value, _ := strconv.Unquote(quoted)
+			fields = append(fields, value)
+			line = line[len(quoted):]
+			continue
+		}
+		end := strings.IndexAny(line, " \t")
+		if end < 0 {
+			end = len(line)
+		}
+		fields = append(fields, line[:end])
+		line = line[end:]
+	}
+}
+
+// Функция для загрузки всех структур перед неинтерактивной работой.
+// Отсутствующие файлы считаются пустыми структурами.
+func loadExisting(data *dataSet, cfg *storageConfig) error {
+	for _, err := range []error{
+		storage.LoadStack(data.stack, cfg.stackFile, cfg.options),
+		storage.LoadQueue(data.queue, cfg.queueFile, cfg.options),
+		storage.LoadPriorityQueue(data.priorityQueue, cfg.priorityQueueFile, cfg.options),
+		storage.LoadDeque(data.deque, cfg.dequeFile, cfg.options),
+		storage.LoadSet(data.set, cfg.setFile, cfg.options),
+		storage.LoadHashTable(data.hashTable, cfg.tableFile, cfg.options),
+	} {
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Функция для запуска серверов: RESP на respAddr и HTTP на httpAddr (пустой
+// адрес отключает сервер). Серверы работают с общими структурами до отмены
+// ctx или ошибки одного из них, после чего все структуры сохраняются.
+func runServers(ctx context.Context, respAddr, httpAddr string, cfg *storageConfig, data *dataSet, log io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	start := func(run func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := run(); err != nil {
+				errs <- err
+				cancel()
+			}
+		}()
+	}
+	if respAddr != "" {
+		start(func() error { return runRESPServer(ctx, respAddr, cfg, data, log) })
+	}
+	if httpAddr != "" {
+		start(func() error { return runHTTPServer(ctx, httpAddr, cfg, data, log) })
+	}
+	wg.Wait()
+	close(errs)
+
+	data.mu.Lock()
+	defer data.mu.Unlock()
+	saved := saveAll(data, cfg, log)
+
+	if err := <-errs; err != nil {
+		return err
+	}
+	if !saved {
+		return fmt.Errorf("не удалось сохранить данные")
+	}
+	return nil
+}
@@ -0,0 +1,361 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/semishida/Laba1/storage"
+)
+
+// Ключи, под которыми структуры доступны по протоколу RESP.
+const (
+	respStackKey = "stack"
+	respQueueKey = "queue"
+	respSetKey   = "set"
+	respTableKey = "table"
+)
+
+// respServer обслуживает подмножество команд Redis поверх общих структур:
+//
+//	LPUSH stack v [v ...], LPOP stack — стек;
+//	RPUSH queue v [v ...], LPOP queue — очередь;
+//	SADD/SREM set m [m ...], SISMEMBER set m, SMEMBERS set — множество;
+//	HSET table f v [f v ...], HGET table f, HDEL table f [f ...] — хеш-таблица;
+//	PING, QUIT, COMMAND.
+//
+// Команды выполняются под блокировкой data.mu, а каждое изменение
+// сохраняется так же, как в интерактивном меню.
+type respServer struct {
+	cfg  *storageConfig
+	data *dataSet
+	log  io.Writer
+}
+
+// Функция для запуска RESP-сервера на адресе addr. Сервер работает до
+// отмены ctx и дожидается завершения обработки всех соединений.
+func runRESPServer(ctx context.Context, addr string, cfg *storageConfig, data *dataSet, log io.Writer) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(log, "RESP-сервер слушает", listener.Addr())
+
+	server := &respServer{cfg: cfg, data: data, log: log}
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	var wg sync.WaitGroup
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			server.serve(ctx, conn)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// serve обрабатывает команды одного клиента до закрытия соединения.
+func (s *respServer) serve(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	reader := bufio.NewReader(conn)
+	out := respWriter{w: bufio.NewWriter(conn)}
+	for {
+		args, err := readRESPCommand(reader)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+				out.error("ERR Protocol error: " + err.Error())
+				out.w.Flush()
+			}
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+		quit := s.execute(out, args)
+		if err := out.w.Flush(); err != nil || quit {
+			return
+		}
+	}
+}
+
+// execute выполняет одну команду и записывает ответ. Возвращает true, если
+// клиент попросил закрыть соединение.
+func (s *respServer) execute(out respWriter, args []string) bool {
+	name := strings.ToUpper(args[0])
+	args = args[1:]
+
+	switch name {
+	case "PING":
+		if len(args) > 0 {
+			out.bulk(args[0])
+		} else {
+			out.simple("PONG")
+		}
+		return false
+	case "QUIT":
+		out.simple("OK")
+		return true
+	case "COMMAND":
+		// redis-cli запрашивает описание команд при подключении.
+		out.array(nil)
+		return false
+	}
+
+	s.data.mu.Lock()
+	defer s.data.mu.Unlock()
+
+	var err error
+	switch name {
+	case "LPUSH":
+		err = s.push(out, args)
+	case "RPUSH":
+		err = s.enqueue(out, args)
+	case "LPOP":
+		err = s.pop(out, args)
+	case "SADD":
+		err = s.setAdd(out, args)
+	case "SREM":
+		err = s.setRemove(out, args)
+	case "SISMEMBER":
+		err = s.setIsMember(out, args)
+	case "SMEMBERS":
+		err = s.setMembers(out, args)
+	case "HSET":
+		err = s.hashSet(out, args)
+	case "HGET":
+		err = s.hashGet(out, args)
+	case "HDEL":
+		err = s.hashDelete(out, args)
+	default:
+		out.error(fmt.Sprintf("ERR unknown command '%s'", strings.ToLower(name)))
+		return false
+	}
+	if err != nil {
+		out.error(err.Error())
+	}
+	return false
+}
+
+func (s *respServer) push(out respWriter, args []string) error {
+	if err := respArgs("lpush", args, 2, respStackKey, false); err != nil {
+		return err
+	}
+	for _, value := range args[1:] {
+		s.data.stack.Push(value)
+		if err := s.cfg.recordStack(s.data.stack, storage.OpPush, value); err != nil {
+			return respStorageError(err)
+		}
+	}
+	out.integer(s.data.stack.Len())
+	return nil
+}
+
+func (s *respServer) enqueue(out respWriter, args []string) error {
+	if err := respArgs("rpush", args, 2, respQueueKey, false); err != nil {
+		return err
+	}
+	for _, value := range args[1:] {
+		s.data.queue.Enqueue(value)
+		if err := s.cfg.recordQueue(s.data.queue, storage.OpEnqueue, value); err != nil {
+			return respStorageError(err)
+		}
+	}
+	out.integer(s.data.queue.Len())
+	return nil
+}
+
+func (s *respServer) pop(out respWriter, args []string) error {
+	if len(args) != 1 {
+		return errWrongArgs("lpop")
+	}
+	switch args[0] {
+	case respStackKey:
+		value, err := s.data.stack.Pop()
+		if err != nil {
+			out.null()
+			return nil
+		}
+		if err := s.cfg.recordStack(s.data.stack, storage.OpPop); err != nil {
+			return respStorageError(err)
+		}
+		out.bulk(value)
+	case respQueueKey:
+		value, err := s.data.queue.Dequeue()
+		if err != nil {
+			out.null()
+			return nil
+		}
+		if err := s.cfg.recordQueue(s.data.queue, storage.OpDequeue); err != nil {
+			return respStorageError(err)
+		}
+		out.bulk(value)
+	default:
+		return errWrongKey(args[0])
+	}
+	return nil
+}
+
+func (s *respServer) setAdd(out respWriter, args []string) error {
+	if err := respArgs("sadd", args, 2, respSetKey, false); err != nil {
+		return err
+	}
+	added := 0
+	for _, member := range args[1:] {
+		if s.data.set.Contains(member) {
+			continue
+		}
+		s.data.set.Add(member)
+		added++
+		if err := s.cfg.recordSet(s.data.set, storage.OpAdd, member); err != nil {
+			return respStorageError(err)
+		}
+	}
+	out.integer(added)
+	return nil
+}
+
+func (s *respServer) setRemove(out respWriter, args []string) error {
+	if err := respArgs("srem", args, 2, respSetKey, false); err != nil {
+		return err
+	}
+	removed := 0
+	for _, member := range args[1:] {
+		if !s.data.set.Contains(member) {
+			continue
+		}
+		s.data.set.Remove(member)
+		removed++
+		if err := s.cfg.recordSet(s.data.set, storage.OpRemove, member); err != nil {
+			return respStorageError(err)
+		}
+	}
+	out.integer(removed)
+	return nil
+}
+
+func (s *respServer) setIsMember(out respWriter, args []string) error {
+	if len(args) != 2 {
+		return errWrongArgs("sismember")
+	}
+	if args[0] != respSetKey {
+		return errWrongKey(args[0])
+	}
+	if s.data.set.Contains(args[1]) {
+		out.integer(1)
+	} else {
+		out.integer(0)
+	}
+	return nil
+}
+
+func (s *respServer) setMembers(out respWriter, args []string) error {
+	if len(args) != 1 {
+		return errWrongArgs("smembers")
+	}
+	if args[0] != respSetKey {
+		return errWrongKey(args[0])
+	}
+	out.array(s.data.set.Values())
+	return nil
+}
+
+func (s *respServer) hashSet(out respWriter, args []string) error {
+	if err := respArgs("hset", args, 3, respTableKey, true); err != nil {
+		return err
+	}
+	added := 0
+	for i := 1; i < len(args); i += 2 {
+		updated, err := s.data.hashTable.Put(args[i], args[i+1])
+		if err != nil {
+			return fmt.Errorf("ERR %v", err)
+		}
+		if !updated {
+			added++
+		}
+		if err := s.cfg.recordHashTable(s.data.hashTable, storage.OpPut, args[i], args[i+1]); err != nil {
+			return respStorageError(err)
+		}
+	}
+	out.integer(added)
+	return nil
+}
+
+func (s *respServer) hashGet(out respWriter, args []string) error {
+	if len(args) != 2 {
+		return errWrongArgs("hget")
+	}
+	if args[0] != respTableKey {
+		return errWrongKey(args[0])
+	}
+	value, found := s.data.hashTable.Get(args[1])
+	if !found {
+		out.null()
+		return nil
+	}
+	out.bulk(value)
+	return nil
+}
+
+func (s *respServer) hashDelete(out respWriter, args []string) error {
+	if err := respArgs("hdel", args, 2, respTableKey, false); err != nil {
+		return err
+	}
+	deleted := 0
+	for _, field := range args[1:] {
+		if _, found := s.data.hashTable.Get(field); !found {
+			continue
+		}
+		s.data.hashTable.Delete(field)
+		deleted++
+		if err := s.cfg.recordHashTable(s.data.hashTable, storage.OpDelete, field); err != nil {
+			return respStorageError(err)
+		}
+	}
+	out.integer(deleted)
+	return nil
+}
+
+// respArgs проверяет аргументы команды вида "ключ значение [значение ...]":
+// не меньше min аргументов, ключ равен key, а при pairs значения идут
+// парами.
+func respArgs(command string, args []string, min int, key string, pairs bool) error {
+	if len(args) < min || (pairs && len(args)%2 == 0) {
+		return errWrongArgs(command)
+	}
+	if args[0] != key {
+		return errWrongKey(args[0])
+	}
+	return nil
+}
+
+func errWrongArgs(command string) error {
+	return fmt.Errorf("ERR wrong number of arguments for '%s' command", command)
+}
+
+func errWrongKey(key string) error {
+	return fmt.Errorf("WRONGTYPE key '%s' does not hold a value of this type", key)
+}
+
+func respStorageError(err error) error {
+	return fmt.Errorf("ERR ошибка сохранения: %v", err)
+}
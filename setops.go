@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/semishida/Laba1/collections"
+	"github.com/semishida/Laba1/storage"
+)
+
+// Функция для выполнения операции над двумя множествами из файлов в формате
+// options.Format. Операции, дающие множество, записывают результат в файл
+// outFile; проверки выводят ответ на экран.
+func runSetOperation(op, fileA, fileB, outFile string, options storage.Options) error {
+	a := collections.NewSet[string]()
+	if err := storage.LoadSet(a, fileA, options); err != nil {
+		return fmt.Errorf("загрузка %s: %w", fileA, err)
+	}
+	b := collections.NewSet[string]()
+	if err := storage.LoadSet(b, fileB, options); err != nil {
+		return fmt.Errorf("загрузка %s: %w", fileB, err)
+	}
+
+	var result *collections.Set[string]
+	switch op {
+	case "union":
+		result = a.Union(b)
+	case "intersect":
+		result = a.Intersect(b)
+	case "difference":
+		result = a.Difference(b)
+	case "symdiff":
+		result = a.SymmetricDifference(b)
+	case "subset":
+		printAnswer(a.IsSubset(b))
+		return nil
+	case "superset":
+		printAnswer(a.IsSuperset(b))
+		return nil
+	case "equal":
+		printAnswer(a.Equal(b))
+		return nil
+	case "disjoint":
+		printAnswer(a.Disjoint(b))
+		return nil
+	default:
+		return fmt.Errorf("неизвестная операция над множествами: %s", op)
+	}
+
+	if outFile == "" {
+		return fmt.Errorf("не указан файл для результата (-set-out)")
+	}
+	if err := storage.SaveSet(result, outFile, options); err != nil {
+		return err
+	}
+	fmt.Printf("Результат записан в %s, элементов: %d\n", outFile, result.Len())
+	return nil
+}
+
+func printAnswer(answer bool) {
+	if answer {
+		fmt.Println("да")
+	} else {
+		fmt.Println("нет")
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/semishida/Laba1/collections"
+	"github.com/semishida/Laba1/storage"
+)
+
+func TestRunSetOperation(t *testing.T) {
+	tests := []struct {
+		op   string
+		want []string
+	}{
+		{"union", []string{"a", "b", "c", "d"}},
+		{"intersect", []string{"b", "c"}},
+		{"difference", []string{"a"}},
+		{"symdiff", []string{"a", "d"}},
+	}
+	for _, format := range []storage.Format{storage.FormatText, storage.FormatJSON, storage.FormatBinary} {
+		for _, tt := range tests {
+			t.Run(string(format)+"/"+tt.op, func(t *testing.T) {
+				dir := t.TempDir()
+				options := storage.Options{Format: format}
+				fileA, fileB := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+				outFile := filepath.Join(dir, "out")
+				saveSet(t, fileA, options, "a", "b", "c")
+				saveSet(t, fileB, options, "b", "c", "d")
+
+				if err := runSetOperation(tt.op, fileA, fileB, outFile, options); err != nil {
+					t.Fatalf("runSetOperation: %v", err)
+				}
+				result := collections.NewSet[string]()
+				if err := storage.LoadSet(result, outFile, options); err != nil {
+					t.Fatalf("LoadSet(%s): %v", outFile, err)
+				}
+				if got := result.Values(); !slices.Equal(got, tt.want) {
+					t.Errorf("результат %q, ожидалось %q", got, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestRunSetOperationErrors(t *testing.T) {
+	dir := t.TempDir()
+	fileA, fileB := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+	saveSet(t, fileA, storage.Options{}, "a")
+	saveSet(t, fileB, storage.Options{}, "b")
+
+	tests := []struct {
+		name              string
+		op, a, b, outFile string
+	}{
+		{"без -set-out", "union", fileA, fileB, ""},
+		{"неизвестная операция", "xor", fileA, fileB, filepath.Join(dir, "out")},
+		{"нет файла", "union", fileA, filepath.Join(dir, "missing"), filepath.Join(dir, "out")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := runSetOperation(tt.op, tt.a, tt.b, tt.outFile, storage.Options{}); err == nil {
+				t.Error("runSetOperation не вернула ошибку")
+			}
+		})
+	}
+}
+
+func saveSet(t *testing.T, filename string, options storage.Options, values ...string) {
+	t.Helper()
+	set := collections.NewSet[string]()
+	for _, value := range values {
+		set.Add(value)
+	}
+	if err := storage.SaveSet(set, filename, options); err != nil {
+		t.Fatalf("SaveSet: %v", err)
+	}
+}
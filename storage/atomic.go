@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"errors"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// BackupSuffix добавляется к имени файла, чтобы получить имя резервной копии
+// предыдущей версии (см. Options.Backup).
+const BackupSuffix = ".bak"
+
+// writeFile атомарно перезаписывает файл данными, которые write выводит во
+// временный файл. Если при записи произошла ошибка, исходный файл остаётся
+// нетронутым. При opts.Backup предыдущая версия сохраняется в
+// filename+BackupSuffix.
+//
+// Новый снимок уже содержит все операции журнала файла, поэтому журнал
+// удаляется. Если снимок отличается от прежнего, журнал удаляется после
+// переименования: при сбое между ними он не совпадёт по контрольной сумме с
+// новым снимком и будет отброшен. Если снимок совпадает с прежним байт в
+// байт, контрольная сумма не помогла бы, и журнал удаляется заранее — при
+// сбое прежний снимок без журнала и так содержит нужное состояние.
+func writeFile(filename string, opts Options, write func(w io.Writer) error) (err error) {
+	dir := filepath.Dir(filename)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(filename)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	hash := crc32.NewIEEE()
+	if err := write(io.MultiWriter(tmp, hash)); err != nil {
+		return err
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if opts.Backup {
+		if err := backupFile(filename); err != nil {
+			return err
+		}
+	}
+	checksum, err := snapshotChecksum(filename)
+	if err != nil {
+		return err
+	}
+	unchanged := checksum == hash.Sum32()
+	if unchanged {
+		if err := removeJournal(filename); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		return err
+	}
+	if !unchanged {
+		if err := removeJournal(filename); err != nil {
+			return err
+		}
+	}
+	return syncDir(dir)
+}
+
+// removeJournal удаляет журнал операций файла, если он есть.
+func removeJournal(filename string) error {
+	err := os.Remove(filename + JournalSuffix)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// backupFile сохраняет текущую версию файла в filename+BackupSuffix. Если
+// файла ещё нет, ничего не делает.
+func backupFile(filename string) error {
+	backup := filename + BackupSuffix
+	if err := os.Remove(backup); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	err := os.Link(filename, backup)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err == nil {
+		return nil
+	}
+
+	// Файловая система может не поддерживать жёсткие ссылки — копируем.
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	return writeFile(backup, Options{}, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// RestoreBackup откатывает файл к предыдущей версии, сохранённой при
+// Options.Backup. Резервная копия при этом переименовывается поверх файла.
+func RestoreBackup(filename string) error {
+	if err := os.Rename(filename+BackupSuffix, filename); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(filename))
+}
+
+// syncDir сбрасывает на диск каталог, чтобы переименование пережило сбой.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// Двоичный снимок имеет следующий вид (все числа — big-endian):
+//
+//	magic   [4]byte  "LABA"
+//	version uint8    binaryVersion
+//	kind    uint8    тип структуры (kindStack, kindQueue, ...)
+//	count   uint32   количество записей
+//	записи: для каждого поля — uint32 длина и байты поля
+//	crc     uint32   CRC32 (IEEE) всех предыдущих байт
+const (
+	binaryMagic   = "LABA"
+	binaryVersion = 1
+	// binaryHeaderSize — размер magic, version, kind и count.
+	binaryHeaderSize = len(binaryMagic) + 1 + 1 + 4
+	crcSize          = 4
+)
+
+// kind — тип структуры, записанной в двоичном снимке.
+type kind uint8
+
+const (
+	kindStack kind = iota + 1
+	kindQueue
+	kindSet
+	kindHashTable
+	kindPriorityQueue
+	kindDeque
+)
+
+// CorruptionError возвращается при загрузке повреждённого двоичного снимка.
+// Структура, в которую выполнялась загрузка, в этом случае не изменяется.
+type CorruptionError struct {
+	Filename string
+	Reason   string
+}
+
+func (e *CorruptionError) Error() string {
+	return fmt.Sprintf("%s: повреждённый снимок: %s", e.Filename, e.Reason)
+}
+
+// writeBinary записывает в w двоичный снимок записей records.
+func writeBinary(w io.Writer, k kind, records [][]string) error {
+	var buf bytes.Buffer
+	buf.WriteString(binaryMagic)
+	buf.WriteByte(binaryVersion)
+	buf.WriteByte(byte(k))
+	buf.Write(binary.BigEndian.AppendUint32(nil, uint32(len(records))))
+	for _, fields := range records {
+		for _, field := range fields {
+			buf.Write(binary.BigEndian.AppendUint32(nil, uint32(len(field))))
+			buf.WriteString(field)
+		}
+	}
+	buf.Write(binary.BigEndian.AppendUint32(nil, crc32.ChecksumIEEE(buf.Bytes())))
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// readBinary проверяет двоичный снимок целиком и только затем вызывает fn
+// для каждой записи из fields полей.
+func readBinary(filename string, k kind, fields int, fn func(fields []string) error) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	records, err := decodeBinary(data, k, fields)
+	if err != nil {
+		return &CorruptionError{Filename: filename, Reason: err.Error()}
+	}
+	for _, record := range records {
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeBinary разбирает содержимое двоичного снимка.
+func decodeBinary(data []byte, k kind, fields int) ([][]string, error) {
+	if len(data) < binaryHeaderSize+crcSize {
+		return nil, fmt.Errorf("файл слишком короткий (%d байт)", len(data))
+	}
+	body, trailer := data[:len(data)-crcSize], data[len(data)-crcSize:]
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(trailer) {
+		return nil, fmt.Errorf("контрольная сумма не совпадает")
+	}
+	if string(body[:len(binaryMagic)]) != binaryMagic {
+		return nil, fmt.Errorf("неверная сигнатура")
+	}
+	body = body[len(binaryMagic):]
+	if body[0] != binaryVersion {
+		return nil, fmt.Errorf("неподдерживаемая версия %d", body[0])
+	}
+	if kind(body[1]) != k {
+		return nil, fmt.Errorf("снимок другой структуры (тип %d, ожидался %d)", body[1], k)
+	}
+	count := binary.BigEndian.Uint32(body[2:6])
+	body = body[6:]
+
+	var records [][]string
+	for i := uint32(0); i < count; i++ {
+		record := make([]string, fields)
+		for j := range record {
+			if len(body) < 4 {
+				return nil, fmt.Errorf("запись %d обрезана", i)
+			}
+			size := binary.BigEndian.Uint32(body)
+			body = body[4:]
+			if uint64(size) > uint64(len(body)) {
+				return nil, fmt.Errorf("запись %d обрезана", i)
+			}
+			record[j] = string(body[:size])
+			body = body[size:]
+		}
+		records = append(records, record)
+	}
+	if len(body) != 0 {
+		return nil, fmt.Errorf("лишние данные после записей (%d байт)", len(body))
+	}
+	return records, nil
+}
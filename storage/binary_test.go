@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/semishida/Laba1/collections"
+)
+
+// writeBinaryStack сохраняет стек a, b, c в двоичном формате и возвращает
+// имя файла и его содержимое.
+func writeBinaryStack(t *testing.T) (string, []byte) {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), "stack.bin")
+	stack := collections.NewStack[string]()
+	for _, value := range []string{"a", "b", "c"} {
+		stack.Push(value)
+	}
+	if err := SaveStack(stack, filename, Options{Format: FormatBinary}); err != nil {
+		t.Fatalf("SaveStack: %v", err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filename, data
+}
+
+// checkCorrupted проверяет, что загрузка файла с содержимым data
+// возвращает *CorruptionError и не меняет стек.
+func checkCorrupted(t *testing.T, filename string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(filename, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stack := collections.NewStack[string]()
+	stack.Push("keep")
+
+	err := LoadStack(stack, filename, Options{Format: FormatBinary})
+	var corruption *CorruptionError
+	if !errors.As(err, &corruption) {
+		t.Fatalf("LoadStack вернула %v, ожидалась *CorruptionError", err)
+	}
+	if corruption.Filename != filename {
+		t.Errorf("CorruptionError.Filename = %q, ожидалось %q", corruption.Filename, filename)
+	}
+	if got := stack.Values(); !slices.Equal(got, []string{"keep"}) {
+		t.Errorf("после ошибки стек изменился: %q", got)
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	filename, _ := writeBinaryStack(t)
+	stack := collections.NewStack[string]()
+	if err := LoadStack(stack, filename, Options{Format: FormatBinary}); err != nil {
+		t.Fatalf("LoadStack: %v", err)
+	}
+	if got, want := stack.Values(), []string{"c", "b", "a"}; !slices.Equal(got, want) {
+		t.Errorf("стек = %q, ожидалось %q", got, want)
+	}
+}
+
+func TestBinaryFlippedByte(t *testing.T) {
+	filename, data := writeBinaryStack(t)
+	for i := range data {
+		corrupted := slices.Clone(data)
+		corrupted[i] ^= 0x01
+		checkCorrupted(t, filename, corrupted)
+	}
+}
+
+func TestBinaryTruncated(t *testing.T) {
+	filename, data := writeBinaryStack(t)
+	for size := 0; size < len(data); size++ {
+		checkCorrupted(t, filename, data[:size])
+	}
+}
+
+func TestBinaryWrongKind(t *testing.T) {
+	filename, data := writeBinaryStack(t)
+	queue := collections.NewQueue[string]()
+	queue.Enqueue("keep")
+
+	if err := os.WriteFile(filename, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := LoadQueue(queue, filename, Options{Format: FormatBinary})
+	var corruption *CorruptionError
+	if !errors.As(err, &corruption) {
+		t.Fatalf("LoadQueue вернула %v, ожидалась *CorruptionError", err)
+	}
+	if got := queue.Values(); !slices.Equal(got, []string{"keep"}) {
+		t.Errorf("после ошибки очередь изменилась: %q", got)
+	}
+}
+
+func TestLoadFailureLeavesStructureUnchanged(t *testing.T) {
+	for _, format := range []Format{FormatText, FormatJSON, FormatBinary} {
+		t.Run(string(format), func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "table")
+			opts := Options{Format: format}
+			full := collections.NewHashTable[string, string](4, nil)
+			for i := range 20 {
+				full.Put(string(rune('a'+i)), "v")
+			}
+			if err := SaveHashTable(full, filename, opts); err != nil {
+				t.Fatalf("SaveHashTable: %v", err)
+			}
+
+			// Таблица не может вместить все записи файла: загрузка
+			// прерывается посередине.
+			table := collections.NewHashTable[string, string](4, nil)
+			table.SetMaxSize(8)
+			table.Put("keep", "1")
+			if err := LoadHashTable(table, filename, opts); !errors.Is(err, collections.ErrTableFull) {
+				t.Fatalf("LoadHashTable вернула %v, ожидалась ErrTableFull", err)
+			}
+			if value, ok := table.Get("keep"); table.Len() != 1 || !ok || value != "1" {
+				t.Errorf("после ошибки таблица изменилась: Len = %d", table.Len())
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/semishida/Laba1/collections"
+)
+
+// SaveDeque сохраняет двустороннюю очередь в файл в формате opts.Format,
+// начиная с первого элемента.
+func SaveDeque(deque *collections.Deque[string], filename string, opts Options) error {
+	return writeFile(filename, opts, func(w io.Writer) error {
+		switch opts.Format {
+		case FormatJSON:
+			return writeJSON(w, deque)
+		case FormatBinary:
+			return writeBinary(w, kindDeque, singleFieldRecords(deque.Values()))
+		default:
+			return writeRecords(w, singleFieldRecords(deque.Values()))
+		}
+	})
+}
+
+// LoadDeque загружает двустороннюю очередь из файла в формате opts.Format:
+// первая запись становится началом очереди. Поверх снимка применяются
+// операции из журнала, если он есть (см. Journal).
+func LoadDeque(target *collections.Deque[string], filename string, opts Options) error {
+	deque := collections.NewDeque[string]()
+	pushBack := func(fields []string) error {
+		deque.PushBack(fields[0])
+		return nil
+	}
+	apply := func(op Op, args []string) error {
+		switch op {
+		case OpPushFront:
+			if err := argCount(op, args, 1); err != nil {
+				return err
+			}
+			deque.PushFront(args[0])
+		case OpPushBack:
+			if err := argCount(op, args, 1); err != nil {
+				return err
+			}
+			deque.PushBack(args[0])
+		case OpPopFront:
+			deque.PopFront()
+		case OpPopBack:
+			deque.PopBack()
+		default:
+			return fmt.Errorf("неизвестная операция двусторонней очереди: %s", op)
+		}
+		return nil
+	}
+	err := loadWithJournal(filename, func() error {
+		switch opts.Format {
+		case FormatJSON:
+			return readJSON(filename, deque)
+		case FormatBinary:
+			return readBinary(filename, kindDeque, 1, pushBack)
+		default:
+			return readRecords(filename, 1, pushBack)
+		}
+	}, apply)
+	return replaceOnSuccess(target, deque, err)
+}
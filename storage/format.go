@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Format определяет формат файлов, в которых хранятся структуры.
+type Format string
+
+const (
+	// FormatText — построчный текстовый формат (см. описание пакета).
+	FormatText Format = "text"
+	// FormatJSON — JSON-документ: массив для стека, очереди и множества,
+	// объект для хеш-таблицы.
+	FormatJSON Format = "json"
+	// FormatBinary — двоичный снимок с контрольной суммой (см. writeBinary).
+	FormatBinary Format = "binary"
+)
+
+// Options задаёт, как структуры сохраняются в файлы и загружаются из них.
+type Options struct {
+	// Format — формат файлов.
+	Format Format
+	// Backup включает сохранение предыдущей версии файла с суффиксом
+	// BackupSuffix при каждой записи.
+	Backup bool
+}
+
+// ParseFormat возвращает формат по его названию.
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(name); format {
+	case FormatText, FormatJSON, FormatBinary:
+		return format, nil
+	default:
+		return "", fmt.Errorf("неизвестный формат файлов: %s", name)
+	}
+}
+
+// writeJSON записывает в w JSON-представление value.
+func writeJSON(w io.Writer, value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// readJSON разбирает JSON-документ из файла в value.
+func readJSON(filename string, value any) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("%s: %w", filename, err)
+	}
+	return nil
+}
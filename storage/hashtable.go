@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/semishida/Laba1/collections"
+)
+
+// SaveHashTable сохраняет хеш-таблицу в файл в формате opts.Format. В текстовом
+// формате каждая запись состоит из двух полей — ключа и значения; пустой
+// ключ допустим и восстанавливается при загрузке.
+func SaveHashTable(hashTable *collections.HashTable[string, string], filename string, opts Options) error {
+	return writeFile(filename, opts, func(w io.Writer) error {
+		switch opts.Format {
+		case FormatJSON:
+			return writeJSON(w, hashTable)
+		case FormatBinary:
+			return writeBinary(w, kindHashTable, hashTableRecords(hashTable))
+		default:
+			return writeRecords(w, hashTableRecords(hashTable))
+		}
+	})
+}
+
+// LoadHashTable загружает хеш-таблицу из файла в формате opts.Format. В
+// текстовых файлах версии 1 строки без разделителя ":" пропускаются. Поверх
+// снимка применяются операции из журнала, если он есть (см. Journal).
+func LoadHashTable(target *collections.HashTable[string, string], filename string, opts Options) error {
+	hashTable := target.NewLike()
+	put := func(fields []string) error {
+		_, err := hashTable.Put(fields[0], fields[1])
+		return err
+	}
+	apply := func(op Op, args []string) error {
+		switch op {
+		case OpPut:
+			if err := argCount(op, args, 2); err != nil {
+				return err
+			}
+			_, err := hashTable.Put(args[0], args[1])
+			return err
+		case OpDelete:
+			if err := argCount(op, args, 1); err != nil {
+				return err
+			}
+			hashTable.Delete(args[0])
+			return nil
+		default:
+			return fmt.Errorf("неизвестная операция хеш-таблицы: %s", op)
+		}
+	}
+	err := loadWithJournal(filename, func() error {
+		switch opts.Format {
+		case FormatJSON:
+			return readJSON(filename, hashTable)
+		case FormatBinary:
+			return readBinary(filename, kindHashTable, 2, put)
+		default:
+			return readRecords(filename, 2, put)
+		}
+	}, apply)
+	return replaceOnSuccess(target, hashTable, err)
+}
+
+// hashTableRecords возвращает записи ключ-значение хеш-таблицы.
+func hashTableRecords(hashTable *collections.HashTable[string, string]) [][]string {
+	var records [][]string
+	hashTable.Range(func(key, value string) bool {
+		records = append(records, []string{key, value})
+		return true
+	})
+	return records
+}
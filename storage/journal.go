@@ -0,0 +1,246 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// JournalSuffix добавляется к имени файла структуры, чтобы получить имя её
+// журнала операций.
+const JournalSuffix = ".wal"
+
+// journalHeader начинает первую строку журнала. За ним следует CRC32 снимка,
+// к которому относятся операции журнала.
+const journalHeader = "#laba1 wal "
+
+// Op — операция, записанная в журнал.
+type Op string
+
+// Операции журнала.
+const (
+	OpPush    Op = "push"
+	OpPop     Op = "pop"
+	OpEnqueue Op = "enqueue"
+	OpDequeue Op = "dequeue"
+	OpAdd     Op = "add"
+	OpRemove  Op = "remove"
+	OpPut     Op = "put"
+	OpDelete  Op = "delete"
+	OpUpdate  Op = "update"
+
+	OpPushFront Op = "push_front"
+	OpPushBack  Op = "push_back"
+	OpPopFront  Op = "pop_front"
+	OpPopBack   Op = "pop_back"
+)
+
+// Journal — журнал операций (write-ahead log) для одного файла структуры.
+// Каждая операция дописывается в конец журнала и сбрасывается на диск, так
+// что изменение стоит O(1) записи. При загрузке функции Load* применяют
+// журнал поверх снимка. Compact записывает новый снимок и очищает журнал.
+//
+// Журнал помнит контрольную сумму снимка, поверх которого он ведётся. Если
+// снимок перезаписан без Compact (или сбой произошёл между записью снимка и
+// очисткой журнала), журнал считается устаревшим и не применяется, поэтому
+// операции никогда не применяются дважды.
+type Journal struct {
+	snapshot string
+	file     *os.File
+	records  int
+}
+
+// OpenJournal открывает журнал файла snapshot для дописывания. Журнал
+// должен открываться после загрузки структуры: устаревший журнал при
+// открытии очищается.
+func OpenJournal(snapshot string) (*Journal, error) {
+	checksum, err := snapshotChecksum(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	lines, valid, err := readJournal(snapshot, checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	journal := &Journal{snapshot: snapshot}
+	if !valid {
+		if err := journal.reset(checksum); err != nil {
+			return nil, err
+		}
+		return journal, nil
+	}
+
+	file, err := os.OpenFile(snapshot+JournalSuffix, os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	journal.file = file
+
+	// Отбрасываем недописанную при сбое последнюю строку, чтобы новые
+	// записи не склеились с ней.
+	size := len(journalHeader) + 8 + 1
+	for _, line := range lines {
+		size += len(line) + 1
+	}
+	if err := file.Truncate(int64(size)); err != nil {
+		file.Close()
+		return nil, err
+	}
+	journal.records = len(lines)
+	return journal, nil
+}
+
+// Append дописывает операцию в журнал и сбрасывает его на диск.
+func (j *Journal) Append(op Op, args ...string) error {
+	line := encodeRecord(append([]string{string(op)}, args...)) + "\n"
+	if _, err := j.file.WriteString(line); err != nil {
+		return err
+	}
+	if err := j.file.Sync(); err != nil {
+		return err
+	}
+	j.records++
+	return nil
+}
+
+// Len возвращает количество операций в журнале с последнего сжатия.
+func (j *Journal) Len() int {
+	return j.records
+}
+
+// Compact вызывает save, которая должна записать полный снимок структуры в
+// файл журнала, и затем очищает журнал. Save* при записи снимка удаляют файл
+// журнала, поэтому он создаётся заново.
+func (j *Journal) Compact(save func() error) error {
+	if err := save(); err != nil {
+		return err
+	}
+	checksum, err := snapshotChecksum(j.snapshot)
+	if err != nil {
+		return err
+	}
+	return j.reset(checksum)
+}
+
+// Close закрывает файл журнала.
+func (j *Journal) Close() error {
+	return j.file.Close()
+}
+
+// reset создаёт пустой журнал, привязанный к снимку с контрольной суммой
+// checksum, и закрывает прежний файл журнала.
+func (j *Journal) reset(checksum uint32) error {
+	file, err := os.OpenFile(j.snapshot+JournalSuffix, os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(file, "%s%08x\n", journalHeader, checksum); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if j.file != nil {
+		j.file.Close()
+	}
+	j.file = file
+	j.records = 0
+	return nil
+}
+
+// replayJournal применяет к структуре операции журнала файла snapshot.
+// Возвращает false, если журнала нет или он устарел.
+func replayJournal(snapshot string, apply func(op Op, args []string) error) (bool, error) {
+	checksum, err := snapshotChecksum(snapshot)
+	if err != nil {
+		return false, err
+	}
+	lines, valid, err := readJournal(snapshot, checksum)
+	if err != nil || !valid {
+		return false, err
+	}
+	for i, line := range lines {
+		fields, err := decodeRecord(line)
+		if err != nil {
+			return false, fmt.Errorf("%s:%d: %w", snapshot+JournalSuffix, i+2, err)
+		}
+		if err := apply(Op(fields[0]), fields[1:]); err != nil {
+			return false, fmt.Errorf("%s:%d: %w", snapshot+JournalSuffix, i+2, err)
+		}
+	}
+	return true, nil
+}
+
+// readJournal читает строки операций журнала. valid равно false, если
+// журнала нет или он относится к другому снимку. Последняя строка без
+// перевода строки считается недописанной и пропускается.
+func readJournal(snapshot string, checksum uint32) (lines []string, valid bool, err error) {
+	data, err := os.ReadFile(snapshot + JournalSuffix)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	end := bytes.LastIndexByte(data, '\n')
+	if end < 0 {
+		return nil, false, nil
+	}
+	all := strings.Split(string(data[:end]), "\n")
+	if all[0] != fmt.Sprintf("%s%08x", journalHeader, checksum) {
+		return nil, false, nil
+	}
+	return all[1:], true, nil
+}
+
+// snapshotChecksum возвращает CRC32 файла снимка. Отсутствующий файл
+// считается пустым.
+func snapshotChecksum(filename string) (uint32, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return 0, err
+	}
+	return crc32.ChecksumIEEE(data), nil
+}
+
+// loadWithJournal загружает снимок функцией loadSnapshot и применяет поверх
+// него журнал. Отсутствие снимка не считается ошибкой, если журнал есть.
+func loadWithJournal(filename string, loadSnapshot func() error, apply func(op Op, args []string) error) error {
+	err := loadSnapshot()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	replayed, journalErr := replayJournal(filename, apply)
+	if journalErr != nil {
+		return journalErr
+	}
+	if replayed {
+		return nil
+	}
+	return err
+}
+
+// replaceOnSuccess заменяет содержимое target загруженной структурой loaded,
+// если загрузка завершилась без ошибки err.
+func replaceOnSuccess[T any](target, loaded *T, err error) error {
+	if err == nil {
+		*target = *loaded
+	}
+	return err
+}
+
+// argCount проверяет количество аргументов операции журнала.
+func argCount(op Op, args []string, want int) error {
+	if len(args) != want {
+		return fmt.Errorf("операция %s: ожидалось аргументов: %d, получено: %d", op, want, len(args))
+	}
+	return nil
+}
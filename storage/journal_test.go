@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/semishida/Laba1/collections"
+)
+
+// loadStackValues загружает стек из файла и возвращает его элементы от
+// вершины.
+func loadStackValues(t *testing.T, filename string) []string {
+	t.Helper()
+	stack := collections.NewStack[string]()
+	if err := LoadStack(stack, filename, Options{}); err != nil {
+		t.Fatalf("LoadStack: %v", err)
+	}
+	return stack.Values()
+}
+
+// saveStackValues сохраняет стек из values (последний — вершина).
+func saveStackValues(t *testing.T, filename string, values ...string) {
+	t.Helper()
+	stack := collections.NewStack[string]()
+	for _, value := range values {
+		stack.Push(value)
+	}
+	if err := SaveStack(stack, filename, Options{}); err != nil {
+		t.Fatalf("SaveStack: %v", err)
+	}
+}
+
+func appendOps(t *testing.T, filename string, ops ...[]string) {
+	t.Helper()
+	journal, err := OpenJournal(filename)
+	if err != nil {
+		t.Fatalf("OpenJournal: %v", err)
+	}
+	defer journal.Close()
+	for _, op := range ops {
+		if err := journal.Append(Op(op[0]), op[1:]...); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+}
+
+func TestJournalReplay(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "stack.txt")
+	saveStackValues(t, filename, "x")
+	appendOps(t, filename, []string{"push", "a"}, []string{"push", "b"}, []string{"pop"})
+
+	if got, want := loadStackValues(t, filename), []string{"a", "x"}; !slices.Equal(got, want) {
+		t.Errorf("после воспроизведения журнала стек = %q, ожидалось %q", got, want)
+	}
+}
+
+func TestJournalWithoutSnapshot(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "stack.txt")
+	appendOps(t, filename, []string{"push", "a"})
+
+	if got, want := loadStackValues(t, filename), []string{"a"}; !slices.Equal(got, want) {
+		t.Errorf("стек = %q, ожидалось %q", got, want)
+	}
+}
+
+func TestJournalTornLastLine(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "stack.txt")
+	saveStackValues(t, filename, "x")
+	appendOps(t, filename, []string{"push", "a"})
+
+	// Имитируем сбой посреди записи операции.
+	file, err := os.OpenFile(filename+JournalSuffix, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString("push:b")
+	file.Close()
+
+	if got, want := loadStackValues(t, filename), []string{"a", "x"}; !slices.Equal(got, want) {
+		t.Fatalf("стек = %q, ожидалось %q", got, want)
+	}
+
+	// Новые операции не должны склеиться с недописанной строкой.
+	appendOps(t, filename, []string{"push", "c"})
+	if got, want := loadStackValues(t, filename), []string{"c", "a", "x"}; !slices.Equal(got, want) {
+		t.Errorf("стек = %q, ожидалось %q", got, want)
+	}
+}
+
+func TestJournalStaleAfterSave(t *testing.T) {
+	tests := []struct {
+		name  string
+		saved []string
+		want  []string
+	}{
+		// Новый снимок совпадает с тем, к которому относится журнал.
+		{"тот же снимок", []string{"x"}, []string{"x"}},
+		{"другой снимок", []string{"y"}, []string{"y"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "stack.txt")
+			saveStackValues(t, filename, "x")
+			appendOps(t, filename, []string{"push", "a"})
+
+			saveStackValues(t, filename, tt.saved...)
+			if got := loadStackValues(t, filename); !slices.Equal(got, tt.want) {
+				t.Errorf("стек = %q, ожидалось %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJournalFromOtherSnapshotIgnored(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "stack.txt")
+	saveStackValues(t, filename, "x")
+	appendOps(t, filename, []string{"push", "a"})
+
+	// Снимок заменён в обход Save* — журнал к нему не относится.
+	if err := os.WriteFile(filename, []byte("#laba1 v2\ny\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := loadStackValues(t, filename), []string{"y"}; !slices.Equal(got, want) {
+		t.Errorf("стек = %q, ожидалось %q", got, want)
+	}
+}
+
+func TestJournalCompact(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "stack.txt")
+	stack := collections.NewStack[string]()
+	journal, err := OpenJournal(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer journal.Close()
+
+	stack.Push("a")
+	if err := journal.Append(OpPush, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := journal.Compact(func() error { return SaveStack(stack, filename, Options{}) }); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	if journal.Len() != 0 {
+		t.Errorf("Len после Compact = %d, ожидалось 0", journal.Len())
+	}
+
+	// Журнал продолжает работать после сжатия.
+	if err := journal.Append(OpPush, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := loadStackValues(t, filename), []string{"b", "a"}; !slices.Equal(got, want) {
+		t.Errorf("стек = %q, ожидалось %q", got, want)
+	}
+}
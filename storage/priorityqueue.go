@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/semishida/Laba1/collections"
+)
+
+// SavePriorityQueue сохраняет очередь с приоритетом в файл в формате
+// opts.Format в порядке извлечения. В текстовом формате каждая запись
+// состоит из двух полей — значения и приоритета.
+func SavePriorityQueue(pq *collections.PriorityQueue[string], filename string, opts Options) error {
+	return writeFile(filename, opts, func(w io.Writer) error {
+		switch opts.Format {
+		case FormatJSON:
+			return writeJSON(w, pq)
+		case FormatBinary:
+			return writeBinary(w, kindPriorityQueue, priorityQueueRecords(pq))
+		default:
+			return writeRecords(w, priorityQueueRecords(pq))
+		}
+	})
+}
+
+// LoadPriorityQueue загружает очередь с приоритетом из файла в формате
+// opts.Format. Элементы с равным приоритетом извлекаются в порядке записей
+// файла. Поверх снимка применяются операции из журнала, если он есть (см.
+// Journal).
+func LoadPriorityQueue(target *collections.PriorityQueue[string], filename string, opts Options) error {
+	pq := collections.NewPriorityQueue[string]()
+	push := func(fields []string) error {
+		priority, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return fmt.Errorf("неверный приоритет %q", fields[1])
+		}
+		pq.Push(fields[0], priority)
+		return nil
+	}
+	apply := func(op Op, args []string) error {
+		switch op {
+		case OpPush:
+			if err := argCount(op, args, 2); err != nil {
+				return err
+			}
+			return push(args)
+		case OpPop:
+			pq.Pop()
+		case OpUpdate:
+			if err := argCount(op, args, 2); err != nil {
+				return err
+			}
+			priority, err := strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("неверный приоритет %q", args[1])
+			}
+			pq.UpdatePriority(args[0], priority)
+		default:
+			return fmt.Errorf("неизвестная операция очереди с приоритетом: %s", op)
+		}
+		return nil
+	}
+	err := loadWithJournal(filename, func() error {
+		switch opts.Format {
+		case FormatJSON:
+			return readJSON(filename, pq)
+		case FormatBinary:
+			return readBinary(filename, kindPriorityQueue, 2, push)
+		default:
+			return readRecords(filename, 2, push)
+		}
+	}, apply)
+	return replaceOnSuccess(target, pq, err)
+}
+
+// priorityQueueRecords возвращает записи значение-приоритет очереди в
+// порядке извлечения.
+func priorityQueueRecords(pq *collections.PriorityQueue[string]) [][]string {
+	items := pq.Items()
+	records := make([][]string, len(items))
+	for i, item := range items {
+		records[i] = []string{item.Value, strconv.Itoa(item.Priority)}
+	}
+	return records
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/semishida/Laba1/collections"
+)
+
+func TestPriorityQueueJournalReplayUpdatesSameItem(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "pq.txt")
+	pq := collections.NewPriorityQueue[string]()
+	journal, err := OpenJournal(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer journal.Close()
+
+	pq.Push("a", 1)
+	pq.Push("a", 5)
+	if err := journal.Compact(func() error { return SavePriorityQueue(pq, filename, Options{}) }); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	pq.UpdatePriority("a", 10)
+	if err := journal.Append(OpUpdate, "a", "10"); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := collections.NewPriorityQueue[string]()
+	if err := LoadPriorityQueue(loaded, filename, Options{}); err != nil {
+		t.Fatalf("LoadPriorityQueue: %v", err)
+	}
+	if got, want := loaded.Items(), pq.Items(); !slices.Equal(got, want) {
+		t.Errorf("после воспроизведения журнала %v, в памяти %v", got, want)
+	}
+}
+
+func TestPriorityQueueRoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatText, FormatJSON, FormatBinary} {
+		t.Run(string(format), func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "pq")
+			opts := Options{Format: format}
+			pq := collections.NewPriorityQueue[string]()
+			pq.Push("low", 1)
+			pq.Push("first", 5)
+			pq.Push("second", 5)
+			pq.Push("high", 9)
+			if err := SavePriorityQueue(pq, filename, opts); err != nil {
+				t.Fatalf("SavePriorityQueue: %v", err)
+			}
+
+			loaded := collections.NewPriorityQueue[string]()
+			if err := LoadPriorityQueue(loaded, filename, opts); err != nil {
+				t.Fatalf("LoadPriorityQueue: %v", err)
+			}
+			if got, want := loaded.Items(), pq.Items(); !slices.Equal(got, want) {
+				t.Errorf("загружено %v, ожидалось %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/semishida/Laba1/collections"
+)
+
+// SaveQueue сохраняет очередь в файл в формате opts.Format, начиная с головы.
+func SaveQueue(queue *collections.Queue[string], filename string, opts Options) error {
+	return writeFile(filename, opts, func(w io.Writer) error {
+		switch opts.Format {
+		case FormatJSON:
+			return writeJSON(w, queue)
+		case FormatBinary:
+			return writeBinary(w, kindQueue, singleFieldRecords(queue.Values()))
+		default:
+			return writeRecords(w, singleFieldRecords(queue.Values()))
+		}
+	})
+}
+
+// LoadQueue загружает очередь из файла в формате opts.Format: первая запись
+// становится головой. Поверх снимка применяются операции из журнала, если он
+// есть (см. Journal).
+func LoadQueue(target *collections.Queue[string], filename string, opts Options) error {
+	queue := collections.NewQueue[string]()
+	enqueue := func(fields []string) error {
+		queue.Enqueue(fields[0])
+		return nil
+	}
+	apply := func(op Op, args []string) error {
+		switch op {
+		case OpEnqueue:
+			if err := argCount(op, args, 1); err != nil {
+				return err
+			}
+			queue.Enqueue(args[0])
+		case OpDequeue:
+			queue.Dequeue()
+		default:
+			return fmt.Errorf("неизвестная операция очереди: %s", op)
+		}
+		return nil
+	}
+	err := loadWithJournal(filename, func() error {
+		switch opts.Format {
+		case FormatJSON:
+			return readJSON(filename, queue)
+		case FormatBinary:
+			return readBinary(filename, kindQueue, 1, enqueue)
+		default:
+			return readRecords(filename, 1, enqueue)
+		}
+	}, apply)
+	return replaceOnSuccess(target, queue, err)
+}
+
+// singleFieldRecords превращает значения в записи из одного поля.
+func singleFieldRecords(values []string) [][]string {
+	records := make([][]string, len(values))
+	for i, value := range values {
+		records[i] = []string{value}
+	}
+	return records
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/semishida/Laba1/collections"
+)
+
+// SaveSet сохраняет множество в файл в формате opts.Format по одному элементу в
+// записи в порядке добавления.
+func SaveSet(set *collections.Set[string], filename string, opts Options) error {
+	return writeFile(filename, opts, func(w io.Writer) error {
+		switch opts.Format {
+		case FormatJSON:
+			return writeJSON(w, set)
+		case FormatBinary:
+			return writeBinary(w, kindSet, singleFieldRecords(set.Values()))
+		default:
+			return writeRecords(w, singleFieldRecords(set.Values()))
+		}
+	})
+}
+
+// LoadSet загружает множество из файла в формате opts.Format. Поверх снимка
+// применяются операции из журнала, если он есть (см. Journal).
+func LoadSet(target *collections.Set[string], filename string, opts Options) error {
+	set := target.NewLike()
+	add := func(fields []string) error {
+		set.Add(fields[0])
+		return nil
+	}
+	apply := func(op Op, args []string) error {
+		if err := argCount(op, args, 1); err != nil {
+			return err
+		}
+		switch op {
+		case OpAdd:
+			set.Add(args[0])
+		case OpRemove:
+			set.Remove(args[0])
+		default:
+			return fmt.Errorf("неизвестная операция множества: %s", op)
+		}
+		return nil
+	}
+	err := loadWithJournal(filename, func() error {
+		switch opts.Format {
+		case FormatJSON:
+			return readJSON(filename, set)
+		case FormatBinary:
+			return readBinary(filename, kindSet, 1, add)
+		default:
+			return readRecords(filename, 1, add)
+		}
+	}, apply)
+	return replaceOnSuccess(target, set, err)
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/semishida/Laba1/collections"
+)
+
+// SaveStack сохраняет стек в файл в формате opts.Format. Элементы записываются
+// от дна к вершине: первая запись (первый элемент JSON-массива) — дно стека,
+// последняя — вершина. В таком порядке LoadStack заново выполняет Push и
+// восстанавливает тот же стек.
+func SaveStack(stack *collections.Stack[string], filename string, opts Options) error {
+	return writeFile(filename, opts, func(w io.Writer) error {
+		switch opts.Format {
+		case FormatJSON:
+			return writeJSON(w, stack)
+		case FormatBinary:
+			return writeBinary(w, kindStack, stackRecords(stack))
+		default:
+			return writeRecords(w, stackRecords(stack))
+		}
+	})
+}
+
+// LoadStack загружает стек из файла в формате opts.Format. Записи читаются от
+// дна к вершине (см. SaveStack), поэтому вершиной становится последняя
+// запись. Поверх снимка применяются операции из журнала, если он есть (см.
+// Journal).
+func LoadStack(target *collections.Stack[string], filename string, opts Options) error {
+	stack := collections.NewStack[string]()
+	push := func(fields []string) error {
+		stack.Push(fields[0])
+		return nil
+	}
+	apply := func(op Op, args []string) error {
+		switch op {
+		case OpPush:
+			if err := argCount(op, args, 1); err != nil {
+				return err
+			}
+			stack.Push(args[0])
+		case OpPop:
+			stack.Pop()
+		default:
+			return fmt.Errorf("неизвестная операция стека: %s", op)
+		}
+		return nil
+	}
+	err := loadWithJournal(filename, func() error {
+		switch opts.Format {
+		case FormatJSON:
+			return readJSON(filename, stack)
+		case FormatBinary:
+			return readBinary(filename, kindStack, 1, push)
+		default:
+			return readRecords(filename, 1, push)
+		}
+	}, apply)
+	return replaceOnSuccess(target, stack, err)
+}
+
+// stackRecords возвращает записи стека от дна к вершине.
+func stackRecords(stack *collections.Stack[string]) [][]string {
+	values := stack.Values()
+	records := make([][]string, 0, len(values))
+	for i := len(values) - 1; i >= 0; i-- {
+		records = append(records, []string{values[i]})
+	}
+	return records
+}
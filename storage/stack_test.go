@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/semishida/Laba1/collections"
+)
+
+func TestStackRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string // в порядке Push
+	}{
+		{"пустой", nil},
+		{"один элемент", []string{"a"}},
+		{"несколько элементов", []string{"a", "b:c", "", "строка\nс переводом"}},
+	}
+	for _, format := range []Format{FormatText, FormatJSON, FormatBinary} {
+		for _, tt := range tests {
+			t.Run(string(format)+"/"+tt.name, func(t *testing.T) {
+				filename := filepath.Join(t.TempDir(), "stack")
+				opts := Options{Format: format}
+
+				stack := collections.NewStack[string]()
+				for _, value := range tt.values {
+					stack.Push(value)
+				}
+				if err := SaveStack(stack, filename, opts); err != nil {
+					t.Fatalf("SaveStack: %v", err)
+				}
+
+				loaded := collections.NewStack[string]()
+				if err := LoadStack(loaded, filename, opts); err != nil {
+					t.Fatalf("LoadStack: %v", err)
+				}
+				if got, want := loaded.Values(), stack.Values(); !slices.Equal(got, want) {
+					t.Errorf("загружен стек %q, ожидалось %q", got, want)
+				}
+				if loaded.Len() != len(tt.values) {
+					t.Errorf("Len = %d, ожидалось %d", loaded.Len(), len(tt.values))
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Package storage сохраняет структуры из пакета collections в файлы и
+// загружает их обратно. Для каждой структуры здесь определён свой формат
+// записи, поэтому изменение файла всегда выполняется полной перезаписью
+// через соответствующую функцию Save*. Перезапись атомарна: данные пишутся
+// во временный файл в том же каталоге, сбрасываются на диск и только затем
+// переименовываются поверх исходного файла. Функции Load* загружают снимок
+// и журнал во временную структуру и заменяют ею содержимое переданной
+// только при успехе, так что при любой ошибке структура не меняется.
+//
+// Файлы записываются в формате версии 2: первая строка — заголовок
+// "#laba1 v2", далее по одной записи в строке. Поля записи разделяются
+// символом ":", а символы "\", ":", перевод строки и возврат каретки внутри
+// полей экранируются как "\\", "\:", "\n" и "\r". Файлы без заголовка
+// читаются в исходном формате версии 1: одно значение в строке без
+// экранирования, а у хеш-таблицы ключ отделяется от значения первым ":".
+package storage
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	// headerPrefix — начало строки заголовка файла любой версии.
+	headerPrefix = "#laba1 v"
+	// formatVersion — версия формата, в которой записываются файлы.
+	formatVersion = 2
+)
+
+// writeRecords записывает в w записи records в текущем текстовом формате.
+func writeRecords(w io.Writer, records [][]string) error {
+	writer := bufio.NewWriter(w)
+	if _, err := fmt.Fprintf(writer, "%s%d\n", headerPrefix, formatVersion); err != nil {
+		return err
+	}
+	for _, fields := range records {
+		if _, err := fmt.Fprintln(writer, encodeRecord(fields)); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+// readRecords вызывает fn для каждой записи файла. Каждая запись должна
+// состоять ровно из fields полей. Версия формата определяется по заголовку.
+func readRecords(filename string, fields int, fn func(fields []string) error) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	version := 1
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if line == "" && err == io.EOF {
+			return nil
+		}
+		line = strings.TrimSuffix(line, "\n")
+
+		if lineNumber == 1 && strings.HasPrefix(line, headerPrefix) {
+			if line != fmt.Sprintf("%s%d", headerPrefix, formatVersion) {
+				return fmt.Errorf("%s: неподдерживаемая версия формата %q", filename, line)
+			}
+			version = formatVersion
+			continue
+		}
+
+		var record []string
+		if version == 1 {
+			record = decodeLegacyRecord(line, fields)
+			if record == nil {
+				continue
+			}
+		} else {
+			record, err = decodeRecord(line)
+			if err == nil && len(record) != fields {
+				err = fmt.Errorf("ожидалось полей: %d, получено: %d", fields, len(record))
+			}
+			if err != nil {
+				return fmt.Errorf("%s:%d: %w", filename, lineNumber, err)
+			}
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
+
+// encodeRecord экранирует поля записи и соединяет их через ":".
+func encodeRecord(fields []string) string {
+	var b strings.Builder
+	for i, field := range fields {
+		if i > 0 {
+			b.WriteByte(':')
+		}
+		for j := 0; j < len(field); j++ {
+			switch c := field[j]; c {
+			case '\\':
+				b.WriteString(`\\`)
+			case ':':
+				b.WriteString(`\:`)
+			case '\n':
+				b.WriteString(`\n`)
+			case '\r':
+				b.WriteString(`\r`)
+			default:
+				b.WriteByte(c)
+			}
+		}
+	}
+	return b.String()
+}
+
+// decodeRecord разбирает строку, записанную encodeRecord.
+func decodeRecord(line string) ([]string, error) {
+	var fields []string
+	var b strings.Builder
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch c {
+		case ':':
+			fields = append(fields, b.String())
+			b.Reset()
+		case '\\':
+			i++
+			if i == len(line) {
+				return nil, errors.New("незавершённая escape-последовательность")
+			}
+			switch line[i] {
+			case '\\':
+				b.WriteByte('\\')
+			case ':':
+				b.WriteByte(':')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			default:
+				return nil, fmt.Errorf("неизвестная escape-последовательность \\%c", line[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return append(fields, b.String()), nil
+}
+
+// decodeLegacyRecord разбирает строку файла версии 1. Для записей из двух
+// полей строка делится по первому ":"; строки без разделителя пропускаются
+// (возвращается nil).
+func decodeLegacyRecord(line string, fields int) []string {
+	if fields == 1 {
+		return []string{line}
+	}
+	key, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return nil
+	}
+	return []string{key, value}
+}